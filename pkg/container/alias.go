@@ -0,0 +1,71 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Alias makes the name `from` resolve to the registration named `to` for type I,
+// without registering the service twice. Resolving ResolveName[I](ctx, sc, from)
+// behaves exactly like resolving the target name, including sharing singleton instances.
+// Aliases may point to other aliases, but alias cycles are rejected.
+//
+// This is useful during renames or migrations, where both names must work temporarily.
+//
+// Example:
+//
+//	Register[*PostgresDB](container, WithName[Database]("db"))
+//
+//	err := Alias[Database](container, "primary-db", "db")
+//	db, err := ResolveName[Database](ctx, container, "primary-db")
+func Alias[I any](sc *ServiceContainer, from, to string) error {
+	key := typeKey[I]()
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	from, to = sc.normalizeName(from), sc.normalizeName(to)
+
+	if from == to {
+		return fmt.Errorf("alias '%s' for '%s' can not point to itself", from, key)
+	}
+
+	if _, exists := sc.services[key][from]; exists {
+		return fmt.Errorf("alias '%s' for '%s' conflicts with an existing registration", from, key)
+	}
+
+	// Follow the chain of the target to guard against alias cycles
+	visited := map[string]bool{from: true}
+	for current, exists := to, true; exists; current, exists = sc.aliases[key][current] {
+		if visited[current] {
+			return fmt.Errorf("alias '%s' for '%s' would create a cycle via '%s'", from, key, current)
+		}
+		visited[current] = true
+	}
+
+	aliasMaps, exists := sc.aliases[key]
+	if !exists {
+		aliasMaps = make(map[string]string)
+		sc.aliases[key] = aliasMaps
+	}
+	aliasMaps[from] = to
+
+	return nil
+}
+
+// resolveAlias follows the alias chain for the provided type and name and returns
+// the name of the registration it ultimately points to. Names without an alias are
+// returned unchanged. The name is normalized before following the chain.
+// The caller must hold the container lock.
+func (sc *ServiceContainer) resolveAlias(key reflect.Type, name string) string {
+	name = sc.normalizeName(name)
+	for i := 0; i <= len(sc.aliases[key]); i++ {
+		target, exists := sc.aliases[key][name]
+		if !exists {
+			break
+		}
+		name = target
+	}
+
+	return name
+}
@@ -0,0 +1,48 @@
+package container
+
+import "testing"
+
+func TestAliasResolvesTargetRegistration(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*CacheService](sc,
+		AsSingleton(),
+		WithName[any]("db")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := Alias[any](sc, "primary-db", "db"); err != nil {
+		t.Fatalf("Failed to create alias: %v", err)
+	}
+
+	db, err := ResolveName[any](ctx, sc, "db")
+	if err != nil {
+		t.Fatalf("Failed to resolve target: %v", err)
+	}
+
+	primary, err := ResolveName[any](ctx, sc, "primary-db")
+	if err != nil {
+		t.Fatalf("Failed to resolve alias: %v", err)
+	}
+
+	if db != primary {
+		t.Error("Expected alias to resolve the same singleton instance")
+	}
+}
+
+func TestAliasRejectsCycles(t *testing.T) {
+	sc := NewServiceContainer()
+
+	if err := Alias[LoggerEngine](sc, "a", "b"); err != nil {
+		t.Fatalf("Failed to create alias: %v", err)
+	}
+
+	if err := Alias[LoggerEngine](sc, "b", "a"); err == nil {
+		t.Error("Expected alias cycle to be rejected")
+	}
+
+	if err := Alias[LoggerEngine](sc, "c", "c"); err == nil {
+		t.Error("Expected self-referencing alias to be rejected")
+	}
+}
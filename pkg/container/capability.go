@@ -0,0 +1,84 @@
+package container
+
+import (
+	"sort"
+	"sync"
+)
+
+// capabilitySet contains the capabilities enabled on a container. It uses its own
+// mutex, allowing capabilities to be checked while the container lock is held.
+type capabilitySet struct {
+	mu sync.RWMutex
+
+	// enabled contains the names of all enabled capabilities
+	enabled map[string]bool
+}
+
+// EnableCapability enables one or more capabilities on the container. Registrations
+// gated via WithCapability only become active once all of their capabilities are
+// enabled. Unlike exclusive environments, capabilities are additive feature toggles.
+// Child containers and scopes inherit the capabilities of their parents.
+//
+// Example:
+//
+//	container.EnableCapability("metrics", "tracing")
+func (sc *ServiceContainer) EnableCapability(names ...string) {
+	sc.capabilities.mu.Lock()
+	defer sc.capabilities.mu.Unlock()
+
+	if sc.capabilities.enabled == nil {
+		sc.capabilities.enabled = make(map[string]bool)
+	}
+
+	for _, name := range names {
+		sc.capabilities.enabled[name] = true
+	}
+}
+
+// Capabilities returns the sorted names of all capabilities active in this container,
+// including the capabilities inherited from parent containers.
+func (sc *ServiceContainer) Capabilities() []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+
+	for current := sc; current != nil; current = current.parent {
+		current.capabilities.mu.RLock()
+		for name := range current.capabilities.enabled {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		current.capabilities.mu.RUnlock()
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// hasCapability reports whether the capability is enabled in this container or one of its parents.
+func (sc *ServiceContainer) hasCapability(name string) bool {
+	for current := sc; current != nil; current = current.parent {
+		current.capabilities.mu.RLock()
+		enabled := current.capabilities.enabled[name]
+		current.capabilities.mu.RUnlock()
+
+		if enabled {
+			return true
+		}
+	}
+
+	return false
+}
+
+// missingCapability returns the first capability required by the registration that is
+// not enabled in this container, or false if the registration is active.
+func (sc *ServiceContainer) missingCapability(service *RegistrationService) (string, bool) {
+	for _, name := range service.Capabilities {
+		if !sc.hasCapability(name) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,70 @@
+package container
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCapabilityGatedRegistration(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine](), WithCapability("logging")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected disabled registration to be ignored, got %v", err)
+	}
+
+	sc.EnableCapability("logging")
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger after enabling capability: %v", err)
+	}
+
+	if capabilities := sc.Capabilities(); !slices.Equal(capabilities, []string{"logging"}) {
+		t.Errorf("Expected capabilities [logging], got %v", capabilities)
+	}
+}
+
+func TestCapabilityResolveAllAndOptional(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, AsNamed("console"), WithName[LoggerEngine]("console"), namedLoggerFactory("console")))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("metrics"), WithName[LoggerEngine]("metrics"), namedLoggerFactory("metrics"), WithCapability("metrics")))
+	errs.Add(Register[*OptionalAgent](sc))
+	errs.Add(Register[*LoggerService](sc, WithCapability("logging")))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	loggers, err := ResolveAll[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve loggers: %v", err)
+	}
+
+	if len(loggers) != 1 || loggers[0].(*NamedLogger).name != "console" {
+		t.Errorf("Expected only the console logger, got %v", loggers)
+	}
+
+	agent, err := Resolve[*OptionalAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger != nil {
+		t.Error("Expected optional field of disabled registration to be nil")
+	}
+
+	child := sc.CreateChild()
+	child.EnableCapability("metrics")
+
+	if capabilities := child.Capabilities(); !slices.Equal(capabilities, []string{"metrics"}) {
+		t.Errorf("Expected capabilities [metrics], got %v", capabilities)
+	}
+}
@@ -0,0 +1,53 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// conditionType is the reflect.Type conditions are registered with
+var conditionType = reflect.TypeOf((func() bool)(nil))
+
+// RegisterCondition registers a named condition that fields can reference via the if
+// flag of their fabric tag, e.g. `fabric:"inject:metrics,if=MetricsEnabled"`. The
+// condition is evaluated whenever such a field is injected: if it reports false, the
+// field is left at its zero value without resolving its dependency. This allows the same
+// struct to be used with optional subsystems that are toggled at runtime.
+//
+// Conditions are registered as func() bool under the provided name, so they can also
+// be provided by modules or factories registering func() bool directly. Referencing a
+// condition that is not registered fails the injection. Validate treats conditional
+// fields like optional ones, since their dependencies may be absent while disabled.
+//
+// Example:
+//
+//	type Handler struct {
+//		Metrics *MetricsClient `fabric:"inject,if=MetricsEnabled"`
+//	}
+//
+//	err := RegisterCondition(container, "MetricsEnabled", func() bool {
+//		return config.Metrics.Enabled
+//	})
+func RegisterCondition(sc *ServiceContainer, name string, condition func() bool) error {
+	if condition == nil {
+		return fmt.Errorf("condition '%s' must not be nil", name)
+	}
+
+	return Register[func() bool](sc, AsNamed(name), WithInstance(condition))
+}
+
+// conditionMet resolves and evaluates the condition registered with the provided name.
+func (sc *ServiceContainer) conditionMet(ctx context.Context, name string) (bool, error) {
+	resolved, err := sc.resolve(ctx, conditionType, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve condition '%s': %w", name, err)
+	}
+
+	condition, ok := resolved.(func() bool)
+	if !ok {
+		return false, fmt.Errorf("condition '%s' of type '%T' is not a func() bool", name, resolved)
+	}
+
+	return condition(), nil
+}
@@ -0,0 +1,68 @@
+package container
+
+import (
+	"errors"
+	"testing"
+)
+
+type ConditionalAgent struct {
+	Logger  LoggerEngine  `fabric:"inject,if=LoggingEnabled"`
+	Encrypt EncryptEngine `fabric:"inject, if=EncryptionEnabled"`
+}
+
+func TestConditionalInjection(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	encryption := false
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*ConditionalAgent](sc))
+	errs.Add(RegisterCondition(sc, "LoggingEnabled", func() bool { return true }))
+	errs.Add(RegisterCondition(sc, "EncryptionEnabled", func() bool { return encryption }))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := sc.Validate(); err != nil {
+		t.Errorf("Expected conditional dependencies to pass validation, got %v", err)
+	}
+
+	agent, err := Resolve[*ConditionalAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger == nil {
+		t.Error("Expected logger to be injected while its condition is met")
+	}
+
+	if agent.Encrypt != nil {
+		t.Error("Expected encrypt to be left nil while its condition is not met")
+	}
+
+	encryption = true
+	if _, err := Resolve[*ConditionalAgent](ctx, sc); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected enabled condition to require the dependency, got %v", err)
+	}
+}
+
+func TestConditionalInjectionUnknownCondition(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+	errs.Add(Register[*ConditionalAgent](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*ConditionalAgent](ctx, sc); err == nil {
+		t.Error("Expected unregistered condition to fail the injection")
+	}
+}
@@ -61,6 +61,15 @@
 //	// Resolve specific implementation
 //	pgDB, err := ResolveName[Database](ctx, container, "postgres")
 //
+// # Generic Services
+//
+// Instantiated generic types are distinct types and can be registered side by side:
+//
+//	Register[*Repository[User]](container, AsSingleton())
+//	Register[*Repository[Order]](container, AsSingleton())
+//
+//	users, err := Resolve[*Repository[User]](ctx, container)
+//
 // # Error Handling
 //
 // All registration and resolution operations return detailed errors.
@@ -72,6 +81,8 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ServiceContainer is the main dependency injection container that manages service
@@ -79,8 +90,10 @@ import (
 // for concurrent access and supports singleton management, middleware processing,
 // and automatic dependency injection via fabric tags.
 //
-// The container maintains separate maps for services and singletons, organized by
-// type and optional names, enabling both unnamed and named service resolution.
+// The container maintains services organized by type and optional names, enabling both
+// unnamed and named service resolution. Singletons are cached per registration, so a
+// singleton resolved via its concrete type or any of its interfaces is the same instance.
+// Middlewares and decorators are applied per requested type and name on top of it.
 type ServiceContainer struct {
 	// mu provides thread-safe access to container state
 	mu sync.RWMutex
@@ -88,17 +101,88 @@ type ServiceContainer struct {
 	// services stores service registrations indexed by type and name
 	services map[reflect.Type]map[string]*RegistrationService
 
-	// singletons caches singleton instances to ensure single instance per registration
-	singletons map[reflect.Type]map[string]any
+	// aliases maps alternative names to the registration names they resolve to, indexed by type
+	aliases map[reflect.Type]map[string]string
+
+	// singletons caches singleton instances to ensure single instance per registration,
+	// indexed by registration and cache key so all types and names mapped to it share the instance
+	singletons map[cacheSlot]any
+
+	// views caches the singletons as processed by the middlewares and decorators of each
+	// type and name they were resolved with, since both are specific to the requested type
+	views map[viewSlot]any
+
+	// instances caches the singletons by the type and name they were resolved with, allowing
+	// cache hits to be served without acquiring the lock
+	instances sync.Map
+
+	// contenders contains registrations that lost an unnamed interface mapping due to their priority
+	contenders map[reflect.Type][]*RegistrationService
+
+	// modules contains the registrations installed by each module via ReloadModule, indexed by token
+	modules map[string][]*RegistrationService
+
+	// recorder records the registrations of the module being reloaded, if this is its staging container
+	recorder *moduleRecorder
+
+	// failures caches initialization errors for singletons registered with CacheFailedInit, indexed by registration and cache key
+	failures map[cacheSlot]error
 
 	// lifecycles contains services that implement cleanup functionality
-	lifecycles []LifecycleService
+	lifecycles []lifecycleEntry
+
+	// initialized indexes the comparable instances contained in lifecycles for constant time lookups
+	initialized map[any]struct{}
+
+	// initializing contains the lifecycle instances whose Init method is currently running,
+	// closing the channel once the initialization has completed
+	initializing map[any]chan struct{}
+
+	// lifecycle collects hooks appended by services via the injectable *Lifecycle
+	lifecycle *Lifecycle
 
 	// middlewares contains services that process resolved instances
-	middlewares []MiddlewareService
+	middlewares []MiddlewareServiceV2
+
+	// cacheHitMiddlewares indicates whether any middleware processes cache hits, disabling the
+	// lock-free lookup of cached instances
+	cacheHitMiddlewares atomic.Bool
+
+	// middlewareKeys maps keys of named middlewares to their index in middlewares
+	middlewareKeys map[string]int
+
+	// decorators contains the decorators wrapping resolved instances, indexed by type
+	decorators map[reflect.Type][]decorator
+
+	// recoverPanics converts panics during resolution into errors
+	recoverPanics bool
+
+	// interfaceFallback resolves interfaces from registrations of interfaces assignable to them
+	interfaceFallback bool
+
+	// shutdownTimeout limits the duration of the shutdown performed by RunUntilSignal
+	shutdownTimeout time.Duration
+
+	// normalizer normalizes registration names before they are stored or looked up
+	normalizer func(string) string
 
 	// tagProcessor manages fabric tag processing for automatic dependency injection
 	tagProcessor *TagProcessorManager
+
+	// timer records construction times when timing is enabled
+	timer resolutionTimer
+
+	// parent is the container this child container falls back to for unknown registrations
+	parent *ServiceContainer
+
+	// scoped indicates whether this container is a scope caching scoped services
+	scoped bool
+
+	// overrides contains the types replaced in a container created by WithOverrides
+	overrides map[reflect.Type]bool
+
+	// capabilities contains the enabled capabilities gating registrations
+	capabilities capabilitySet
 }
 
 // NewServiceContainer creates a new dependency injection container with default
@@ -115,18 +199,87 @@ type ServiceContainer struct {
 //	container := NewServiceContainer()
 //	defer container.Cleanup(context.Background())
 func NewServiceContainer() *ServiceContainer {
-	sc := &ServiceContainer{
-		services:     make(map[reflect.Type]map[string]*RegistrationService),
-		singletons:   make(map[reflect.Type]map[string]any),
-		lifecycles:   make([]LifecycleService, 0),
-		tagProcessor: NewTagProcessorManager(),
-	}
-	// Register the inject processor by default when creating a new container
-	sc.AddTagProcessor(NewInjectTagProcessor())
+	sc := &ServiceContainer{}
+	sc.initialize()
 
 	return sc
 }
 
+// initialize puts the container into its just-constructed state, discarding all
+// registrations, cached instances and settings, and registers its defaults. The
+// parent of a child container and the scope of a scope are kept.
+func (sc *ServiceContainer) initialize() {
+	sc.mu.Lock()
+	sc.services = make(map[reflect.Type]map[string]*RegistrationService)
+	sc.aliases = make(map[reflect.Type]map[string]string)
+	sc.singletons = make(map[cacheSlot]any)
+	sc.views = make(map[viewSlot]any)
+	sc.instances.Clear()
+	sc.failures = make(map[cacheSlot]error)
+	sc.contenders = make(map[reflect.Type][]*RegistrationService)
+	sc.modules = make(map[string][]*RegistrationService)
+	sc.recorder = nil
+	sc.lifecycles = make([]lifecycleEntry, 0)
+	sc.initialized = make(map[any]struct{})
+	sc.initializing = make(map[any]chan struct{})
+	sc.lifecycle = newLifecycle()
+	sc.middlewares = nil
+	sc.cacheHitMiddlewares.Store(false)
+	sc.middlewareKeys = make(map[string]int)
+	sc.decorators = make(map[reflect.Type][]decorator)
+	sc.recoverPanics = false
+	sc.interfaceFallback = false
+	sc.shutdownTimeout = DefaultShutdownTimeout
+	sc.normalizer = nil
+	sc.tagProcessor = NewTagProcessorManager()
+	sc.overrides = nil
+	sc.mu.Unlock()
+
+	sc.timer.mu.Lock()
+	sc.timer.enabled = false
+	sc.timer.threshold = 0
+	sc.timer.stats = nil
+	sc.timer.mu.Unlock()
+
+	sc.capabilities.mu.Lock()
+	sc.capabilities.enabled = nil
+	sc.capabilities.mu.Unlock()
+
+	// Register the inject and name processors by default when creating a new container
+	sc.AddTagProcessor(NewInjectTagProcessor(), NewNameTagProcessor())
+
+	// Register the lifecycle to make it injectable, which can not fail for an instance
+	_ = Register[*Lifecycle](sc, WithInstance(sc.lifecycle), asInternal())
+
+	// Register a read-only view of the container, which can not fail for an instance
+	_ = Register[ContainerInfo](sc, WithInstance(&containerInfo{sc: sc}), asInternal())
+}
+
+// Reset cleans up all lifecycle services and hooks like Cleanup and returns the container
+// to its just-constructed state. All registrations, cached singletons, middlewares,
+// decorators, tag processors, capabilities and settings are discarded, and only the
+// default tag processors and services registered by NewServiceContainer are re-added.
+// After Reset, the container is empty and ready for fresh registration. A child container
+// keeps its parent, but no longer inherits the settings copied by CreateChild.
+//
+// Cleanup errors are aggregated and returned, but the container is reset regardless.
+// Reset must not be called concurrently with registrations or resolutions. It is mainly
+// useful for test suites reusing a single container.
+//
+// Example:
+//
+//	t.Cleanup(func() {
+//		if err := container.Reset(context.Background()); err != nil {
+//			t.Errorf("Failed to reset container: %v", err)
+//		}
+//	})
+func (sc *ServiceContainer) Reset(ctx context.Context) error {
+	err := sc.Cleanup(ctx)
+	sc.initialize()
+
+	return err
+}
+
 // Cleanup performs cleanup of all registered lifecycle services in reverse order
 // of registration. This ensures that services are cleaned up in the opposite
 // order they were registered, maintaining proper dependency cleanup order.
@@ -134,6 +287,12 @@ func NewServiceContainer() *ServiceContainer {
 // The method collects all cleanup errors and returns them as a single error.
 // If no errors occur during cleanup, it returns nil.
 //
+// Before cleaning up lifecycle services, the OnStop callbacks of all hooks appended
+// to the container's Lifecycle are called in reverse order.
+//
+// Cleanup is idempotent: each service is only cleaned up once, so calling Cleanup
+// again only affects services initialized since the previous call.
+//
 // It should typically be called when the application shuts down to ensure
 // proper resource cleanup.
 //
@@ -145,9 +304,19 @@ func NewServiceContainer() *ServiceContainer {
 //		}
 //	}()
 func (sc *ServiceContainer) Cleanup(ctx context.Context) error {
+	sc.mu.Lock()
+	lifecycles := sc.lifecycles
+	sc.lifecycles = make([]lifecycleEntry, 0)
+	sc.initialized = make(map[any]struct{})
+	sc.mu.Unlock()
+
 	errs := &Errors{}
-	for i := len(sc.lifecycles) - 1; i >= 0; i-- {
-		if err := sc.lifecycles[i].Cleanup(ctx); err != nil {
+	if err := sc.lifecycle.stop(ctx); err != nil {
+		errs.Add(fmt.Errorf("error during container cleanup: %w", err))
+	}
+
+	for _, lifecycle := range cleanupOrder(lifecycles) {
+		if err := lifecycle.Cleanup(ctx); err != nil {
 			errs.Add(fmt.Errorf("error during container cleanup: %w", err))
 		}
 	}
@@ -170,11 +339,53 @@ func (sc *ServiceContainer) Cleanup(ctx context.Context) error {
 //		&ValidationMiddleware{},
 //	)
 func (sc *ServiceContainer) AddMiddleware(middlewares ...MiddlewareService) {
+	sc.mu.Lock()
+	sc.middlewares = append(sc.middlewares, adaptMiddlewares(middlewares)...)
+	sc.mu.Unlock()
+}
+
+// AddMiddlewareV2 registers one or more middlewares receiving information about the
+// resolution, including whether the instance was returned from the cache. They are
+// executed in the order they are registered, interleaved with middlewares registered
+// via AddMiddleware.
+//
+// Example:
+//
+//	container.AddMiddlewareV2(&ConstructionLogger{})
+func (sc *ServiceContainer) AddMiddlewareV2(middlewares ...MiddlewareServiceV2) {
 	sc.mu.Lock()
 	sc.middlewares = append(sc.middlewares, middlewares...)
+	if processesCacheHits(middlewares) {
+		sc.cacheHitMiddlewares.Store(true)
+	}
 	sc.mu.Unlock()
 }
 
+// AddNamedMiddleware registers a middleware under the given key. If a middleware
+// with the same key has already been registered, it is replaced in place, keeping
+// its original execution order. This makes wiring idempotent, e.g. when a module
+// adding middlewares is installed more than once.
+//
+// Middlewares added via AddMiddleware remain append-only for backward compatibility.
+//
+// Example:
+//
+//	container.AddNamedMiddleware("logging", &LoggingMiddleware{})
+//	container.AddNamedMiddleware("logging", &LoggingMiddleware{}) // replaces the first
+func (sc *ServiceContainer) AddNamedMiddleware(key string, middleware MiddlewareService) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	adapted := &middlewareAdapter{middleware: middleware}
+	if index, exists := sc.middlewareKeys[key]; exists {
+		sc.middlewares[index] = adapted
+		return
+	}
+
+	sc.middlewareKeys[key] = len(sc.middlewares)
+	sc.middlewares = append(sc.middlewares, adapted)
+}
+
 // AddTagProcessor registers one or more custom tag processors that handle
 // fabric tag processing during service creation. Tag processors enable
 // automatic dependency injection based on struct field tags.
@@ -191,6 +402,37 @@ func (sc *ServiceContainer) AddTagProcessor(processor ...TagProcessor) {
 	sc.mu.Unlock()
 }
 
+// AddNamedTagProcessor registers a tag processor under the given key. If a processor
+// with the same key has already been registered, it is replaced. This makes wiring
+// idempotent, e.g. when a module adding processors is installed more than once.
+//
+// Processors added via AddTagProcessor remain append-only for backward compatibility.
+//
+// Example:
+//
+//	container.AddNamedTagProcessor("config", NewConfigTagProcessor[*AppConfig]())
+func (sc *ServiceContainer) AddNamedTagProcessor(key string, processor TagProcessor) {
+	sc.mu.Lock()
+	sc.tagProcessor.registerNamedProcessor(key, processor)
+	sc.mu.Unlock()
+}
+
+// TagProcessors returns a copy of all registered tag processors in priority order
+// (higher priority first), including the default InjectTagProcessor. Modifying the
+// returned slice does not affect the container.
+//
+// Example:
+//
+//	for _, processor := range container.TagProcessors() {
+//		log.Printf("%T (priority %d)", processor, processor.GetPriority())
+//	}
+func (sc *ServiceContainer) TagProcessors() []TagProcessor {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	return sc.tagProcessor.getProcessors()
+}
+
 // ResolveByType resolves a service by its reflect.Type. This method is primarily
 // used internally by tag processors during fabric tag processing.
 //
@@ -198,42 +440,46 @@ func (sc *ServiceContainer) AddTagProcessor(processor ...TagProcessor) {
 // and the resolved instance if successful. If the service is not found or
 // resolution fails, it returns false and nil.
 //
-// This method handles singleton caching automatically and applies the service's
-// factory function if needed.
+// This method runs the same resolution pipeline as Resolve, including singleton
+// caching, middleware processing and lifecycle initialization.
 func (sc *ServiceContainer) ResolveByType(ctx context.Context, t reflect.Type) (bool, any) {
-	sc.mu.RLock()
-	serviceMaps, exists := sc.services[t]
-	sc.mu.RUnlock()
-
-	if !exists {
+	instance, err := sc.resolve(ctx, t, "")
+	if err != nil {
 		return false, nil
 	}
 
-	service, exists := serviceMaps[""]
-	if !exists {
-		return false, nil
-	}
+	return true, instance
+}
 
-	if service.IsSingleton {
-		sc.mu.RLock()
-		singletonsMaps, exists := sc.singletons[t]
-		sc.mu.RUnlock()
+// isRegistered reports whether a registration exists for the provided type and name,
+// taking aliases and parent containers into account.
+func (sc *ServiceContainer) isRegistered(t reflect.Type, name string) bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
 
-		if exists {
-			if singleton, exists := singletonsMaps[""]; exists {
-				return true, singleton
-			}
-		}
+	if service, exists := sc.services[t][sc.resolveAlias(t, name)]; exists {
+		_, missing := sc.missingCapability(service)
+		return !missing
 	}
 
-	if service.Factory == nil {
-		return false, nil
-	}
+	return sc.parent != nil && sc.parent.isRegistered(t, name)
+}
 
-	factory, err := service.Factory(ctx, sc)
-	if err != nil {
-		return false, nil
-	}
+// SetRecoverPanics controls whether panics raised during resolution by factories,
+// middlewares, decorators or lifecycle Init methods are recovered. If enabled, a panic
+// is converted into an error wrapping ErrPanic, naming the service type and including
+// the recovered value and stack trace. This is useful when third-party factories, e.g.
+// of plugins, can not be trusted. Disabled by default, preserving normal Go semantics.
+//
+// Example:
+//
+//	container.SetRecoverPanics(true)
+//	if _, err := Resolve[Plugin](ctx, container); errors.Is(err, ErrPanic) {
+//		log.Printf("Plugin crashed during construction: %v", err)
+//	}
+func (sc *ServiceContainer) SetRecoverPanics(enabled bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
 
-	return true, factory
+	sc.recoverPanics = enabled
 }
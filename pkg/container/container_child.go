@@ -0,0 +1,365 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// CreateChild creates a new child container that falls back to this container for
+// all registrations it does not define itself. Registrations added to the child
+// shadow registrations of the parent with the same type and name, without affecting
+// the parent or its other children.
+//
+// The child starts with copies of the parent's tag processors, global middlewares and decorators.
+// Services resolved from a parent registration are created, cached and cleaned up by
+// the parent, while the child only manages its own registrations.
+//
+// Example:
+//
+//	child := container.CreateChild()
+//	Register[*MockDB](child, WithName[Database]("pg"))
+//
+//	// Resolves the child's "pg", all other services from the parent
+//	db, err := ResolveName[Database](ctx, child, "pg")
+func (sc *ServiceContainer) CreateChild() *ServiceContainer {
+	child := NewServiceContainer()
+	child.parent = sc
+
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	child.tagProcessor.entries = append([]tagProcessorEntry{}, sc.tagProcessor.entries...)
+	child.tagProcessor.sortProcessors()
+
+	child.middlewares = append(child.middlewares, sc.middlewares...)
+	child.cacheHitMiddlewares.Store(sc.cacheHitMiddlewares.Load())
+	for key, index := range sc.middlewareKeys {
+		child.middlewareKeys[key] = index
+	}
+
+	child.normalizer = sc.normalizer
+	child.recoverPanics = sc.recoverPanics
+	child.interfaceFallback = sc.interfaceFallback
+
+	for key, decorators := range sc.decorators {
+		child.decorators[key] = append([]decorator{}, decorators...)
+	}
+
+	return child
+}
+
+// Parent returns the container this child container falls back to, or nil if this is
+// a root container. Scopes and containers created via WithOverrides are children of
+// the container they were created from.
+func (sc *ServiceContainer) Parent() *ServiceContainer {
+	return sc.parent
+}
+
+// Root returns the root of the container hierarchy, which is this container itself if
+// it has no parent. Registering services on the root from a child is allowed, e.g. to
+// register app-global services from a request scope, but affects all of its children.
+//
+// Example:
+//
+//	err := Register[*SessionStore](scope.Root(), AsSingleton())
+func (sc *ServiceContainer) Root() *ServiceContainer {
+	root := sc
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	return root
+}
+
+// ResolveAll resolves every registration of type T, regardless of its name. When called
+// on a child container, the registrations of all ancestors are included as well, with
+// child registrations shadowing parent registrations of the same name. Registrations
+// that only exist in an ancestor are resolved from that ancestor. Registrations gated
+// by disabled capabilities are skipped.
+//
+// The returned instances are ordered by registration name, followed by registrations
+// that lost an unnamed mapping due to their priority (see WithPriority), ordered by
+// priority and resolved via their concrete type.
+//
+// Example:
+//
+//	Register[*PostgresDB](container, WithName[Database]("pg"))
+//	Register[*MySQLDB](container, WithName[Database]("mysql"))
+//
+//	databases, err := ResolveAll[Database](ctx, container)
+func ResolveAll[T any](ctx context.Context, sc *ServiceContainer) ([]T, error) {
+	key := typeKey[T]()
+	names := sc.registrationNames(key)
+
+	result := make([]T, 0, len(names))
+	for _, name := range names {
+		typed, err := resolveAllEntry[T](ctx, sc, key, name)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, typed)
+	}
+
+	// Registrations that lost an unnamed mapping due to their priority are resolved via their concrete type
+	for _, service := range sc.contendersFor(key) {
+		typed, err := resolveAllEntry[T](ctx, sc, service.Type, service.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, typed)
+	}
+
+	return result, nil
+}
+
+// ResolveAllNamed resolves every registration of type T like ResolveAll, but returns
+// the instances keyed by the name they were registered under, using the empty string
+// for the default registration. Every entry is resolved through the full pipeline,
+// respecting singleton caching and middlewares. An instance registered under multiple
+// names, e.g. a pre-created instance, appears under each of its names.
+//
+// Example:
+//
+//	handlers, err := ResolveAllNamed[Handler](ctx, container)
+//	if handler, ok := handlers[request.Type]; ok {
+//		handler.Handle(request)
+//	}
+func ResolveAllNamed[T any](ctx context.Context, sc *ServiceContainer) (map[string]T, error) {
+	key := typeKey[T]()
+	names := sc.registrationNames(key)
+
+	result := make(map[string]T, len(names))
+	for _, name := range names {
+		typed, err := resolveAllEntry[T](ctx, sc, key, name)
+		if err != nil {
+			return nil, err
+		}
+
+		result[name] = typed
+	}
+
+	return result, nil
+}
+
+// ResolveAllChan resolves every registration of type T like ResolveAll, but constructs
+// the instances lazily in a separate goroutine and streams them through the returned
+// channel, so the consumer can start processing before all instances are built. The
+// next instance is only constructed once the previous one has been received.
+//
+// Instances are streamed in the same order as returned by ResolveAll, although callers
+// should not rely on any particular order. Production stops at the first failed
+// resolution or once the context is cancelled, sending the error to the error channel.
+// Both channels are closed once production has stopped, and the error channel receives
+// at most one error.
+//
+// Example:
+//
+//	plugins, errs := ResolveAllChan[Plugin](ctx, container)
+//	for plugin := range plugins {
+//		plugin.Start()
+//	}
+//	if err := <-errs; err != nil {
+//		return err
+//	}
+func ResolveAllChan[T any](ctx context.Context, sc *ServiceContainer) (<-chan T, <-chan error) {
+	key := typeKey[T]()
+
+	entries := make([]instanceKey, 0)
+	for _, name := range sc.registrationNames(key) {
+		entries = append(entries, instanceKey{key: key, name: name})
+	}
+	// Registrations that lost an unnamed mapping due to their priority are resolved via their concrete type
+	for _, service := range sc.contendersFor(key) {
+		entries = append(entries, instanceKey{key: service.Type, name: service.Name})
+	}
+
+	instances := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(instances)
+
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+
+			typed, err := resolveAllEntry[T](ctx, sc, entry.key, entry.name)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case instances <- typed:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return instances, errs
+}
+
+// registrationNames returns the sorted names of all active registrations of the
+// provided type in this container and its ancestors.
+func (sc *ServiceContainer) registrationNames(key reflect.Type) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for current := sc; current != nil; current = current.parent {
+		current.mu.RLock()
+		for name, service := range current.services[key] {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			// Registrations gated by disabled capabilities are skipped, but still shadow their parents
+			if _, missing := current.missingCapability(service); !missing {
+				names = append(names, name)
+			}
+		}
+		current.mu.RUnlock()
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// contendersFor returns the active registrations of this container and its ancestors that
+// lost the unnamed mapping of the provided type due to their priority, ordered by priority.
+func (sc *ServiceContainer) contendersFor(key reflect.Type) []*RegistrationService {
+	seen := make(map[*RegistrationService]bool)
+	contenders := make([]*RegistrationService, 0)
+	for current := sc; current != nil; current = current.parent {
+		current.mu.RLock()
+		for _, service := range current.contenders[key] {
+			if seen[service] {
+				continue
+			}
+			seen[service] = true
+
+			if _, missing := current.missingCapability(service); !missing {
+				contenders = append(contenders, service)
+			}
+		}
+		current.mu.RUnlock()
+	}
+
+	sort.SliceStable(contenders, func(i, j int) bool {
+		return contenders[i].Priority > contenders[j].Priority
+	})
+
+	return contenders
+}
+
+// resolveAllEntry resolves a single registration of type T for ResolveAll and ResolveAllNamed.
+func resolveAllEntry[T any](ctx context.Context, sc *ServiceContainer, key reflect.Type, name string) (T, error) {
+	var zero T
+
+	resolved, err := sc.resolve(ctx, key, name)
+	if err != nil {
+		return zero, fmt.Errorf("failed to resolve '%s' with name '%s': %w", key, name, err)
+	}
+
+	typed, ok := resolved.(T)
+	if !ok {
+		return zero, fmt.Errorf("resolved value of type '%T' with name '%s' is not assignable to '%s'", resolved, name, key)
+	}
+
+	return typed, nil
+}
+
+// ResolveWhere resolves every registration of type T like ResolveAll and returns only
+// the instances satisfying the provided predicate. Since the predicate is evaluated on
+// the constructed instances, all registrations of type T are constructed, including
+// those that are filtered out.
+//
+// Example:
+//
+//	exporters, err := ResolveWhere(ctx, container, func(e Exporter) bool {
+//		return e.Supports("json")
+//	})
+func ResolveWhere[T any](ctx context.Context, sc *ServiceContainer, pred func(T) bool) ([]T, error) {
+	all, err := ResolveAll[T](ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, 0, len(all))
+	for _, instance := range all {
+		if pred(instance) {
+			result = append(result, instance)
+		}
+	}
+
+	return result, nil
+}
+
+// ResolveAllAssignable resolves every registration whose concrete type is assignable
+// to T, regardless of the type keys and interfaces it was registered with. This allows
+// cross-cutting discovery, e.g. of all io.Closer implementations, without requiring
+// every registration to declare the interface via With[T]().
+//
+// Unlike ResolveAll, this scans all registrations of the container and its ancestors,
+// which is proportional to the total number of registrations and constructs every
+// matching service. It should therefore not be used on hot paths. Registrations gated
+// by disabled capabilities and the default registrations of every container, *Lifecycle
+// and ContainerInfo, are skipped. The returned instances are ordered by concrete type
+// and registration name.
+//
+// Example:
+//
+//	closers, err := ResolveAllAssignable[io.Closer](ctx, container)
+func ResolveAllAssignable[T any](ctx context.Context, sc *ServiceContainer) ([]T, error) {
+	target := typeKey[T]()
+
+	seen := make(map[dependency]bool)
+	matches := make([]dependency, 0)
+	for current := sc; current != nil; current = current.parent {
+		current.mu.RLock()
+		for _, root := range current.sortedRegistrations() {
+			if seen[root] {
+				continue
+			}
+			seen[root] = true
+
+			if !root.Type.AssignableTo(target) {
+				continue
+			}
+
+			if _, missing := current.missingCapability(current.services[root.Type][root.Name]); !missing {
+				matches = append(matches, root)
+			}
+		}
+		current.mu.RUnlock()
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].String() < matches[j].String()
+	})
+
+	result := make([]T, 0, len(matches))
+	for _, match := range matches {
+		resolved, err := sc.resolve(ctx, match.Type, match.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve '%s' with name '%s': %w", match.Type, match.Name, err)
+		}
+
+		typed, ok := resolved.(T)
+		if !ok {
+			return nil, fmt.Errorf("resolved value of type '%T' with name '%s' is not assignable to '%s'", resolved, match.Name, target)
+		}
+
+		result = append(result, typed)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,330 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type NamedLogger struct {
+	name string
+}
+
+func (nl *NamedLogger) Debug(msg string, args ...any) {}
+
+func namedLoggerFactory(name string) RegistrationOption {
+	return AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return &NamedLogger{name: name}, nil
+	})
+}
+
+func TestChildContainerFallback(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine](), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	child := sc.CreateChild()
+
+	fromChild, err := Resolve[LoggerEngine](ctx, child)
+	if err != nil {
+		t.Fatalf("Failed to resolve parent registration from child: %v", err)
+	}
+
+	fromParent, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve from parent: %v", err)
+	}
+
+	if fromChild != fromParent {
+		t.Error("Expected parent singleton to be shared with child")
+	}
+}
+
+func TestResolveAllAcrossChildContainers(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, AsNamed("parent-pg"), WithName[LoggerEngine]("pg"), namedLoggerFactory("parent-pg")))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("parent-mysql"), WithName[LoggerEngine]("mysql"), namedLoggerFactory("parent-mysql")))
+
+	child := sc.CreateChild()
+	errs.Add(Register[*NamedLogger](child, AsNamed("child-pg"), WithName[LoggerEngine]("pg"), namedLoggerFactory("child-pg")))
+	errs.Add(Register[*NamedLogger](child, AsNamed("child-redis"), WithName[LoggerEngine]("redis"), namedLoggerFactory("child-redis")))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	loggers, err := ResolveAll[LoggerEngine](ctx, child)
+	if err != nil {
+		t.Fatalf("Failed to resolve all loggers: %v", err)
+	}
+
+	expected := []string{"parent-mysql", "child-pg", "child-redis"}
+	if len(loggers) != len(expected) {
+		t.Fatalf("Expected %d loggers, got %d", len(expected), len(loggers))
+	}
+
+	for i, logger := range loggers {
+		if name := logger.(*NamedLogger).name; name != expected[i] {
+			t.Errorf("Expected logger %d to be '%s', got '%s'", i, expected[i], name)
+		}
+	}
+
+	parentLoggers, err := ResolveAll[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve all loggers: %v", err)
+	}
+
+	if len(parentLoggers) != 2 {
+		t.Errorf("Expected child registrations not to affect parent, got %d loggers", len(parentLoggers))
+	}
+}
+
+func TestResolveWhere(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, AsNamed("json"), WithName[LoggerEngine]("json"), namedLoggerFactory("json")))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("text"), WithName[LoggerEngine]("text"), namedLoggerFactory("text")))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("json-pretty"), WithName[LoggerEngine]("json-pretty"), namedLoggerFactory("json-pretty")))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	loggers, err := ResolveWhere(ctx, sc, func(l LoggerEngine) bool {
+		return strings.HasPrefix(l.(*NamedLogger).name, "json")
+	})
+	if err != nil {
+		t.Fatalf("Failed to resolve loggers: %v", err)
+	}
+
+	if len(loggers) != 2 {
+		t.Fatalf("Expected 2 loggers, got %d", len(loggers))
+	}
+
+	for i, expected := range []string{"json", "json-pretty"} {
+		if name := loggers[i].(*NamedLogger).name; name != expected {
+			t.Errorf("Expected logger '%s' at index %d, got '%s'", expected, i, name)
+		}
+	}
+}
+
+func TestResolveAllAssignable(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*ClosableLogger](sc, With[LoggerEngine]()))
+	errs.Add(Register[*ClosableLogger](sc, AsNamed("audit")))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	child := sc.CreateChild()
+	if err := Register[*ClosableLogger](child, AsNamed("request")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	closers, err := ResolveAllAssignable[io.Closer](ctx, child)
+	if err != nil {
+		t.Fatalf("Failed to resolve closers: %v", err)
+	}
+
+	if len(closers) != 3 {
+		t.Fatalf("Expected 3 closers, got %d", len(closers))
+	}
+
+	for _, closer := range closers {
+		if _, ok := closer.(*ClosableLogger); !ok {
+			t.Errorf("Expected only closable loggers, got %T", closer)
+		}
+	}
+}
+
+func TestResolveAllNamed(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	shared := &NamedLogger{name: "shared"}
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine](), AsSingleton()))
+	errs.Add(Register[*NamedLogger](sc, WithInstance(shared), WithName[LoggerEngine]("primary"), WithName[LoggerEngine]("secondary")))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	loggers, err := ResolveAllNamed[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve loggers: %v", err)
+	}
+
+	if len(loggers) != 3 {
+		t.Fatalf("Expected 3 loggers, got %d", len(loggers))
+	}
+
+	if _, ok := loggers[""].(*LoggerService); !ok {
+		t.Errorf("Expected default logger under the empty name, got %T", loggers[""])
+	}
+
+	if loggers["primary"] != shared || loggers["secondary"] != shared {
+		t.Error("Expected shared instance under each of its names")
+	}
+
+	again, err := ResolveAllNamed[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve loggers: %v", err)
+	}
+
+	if again[""] != loggers[""] {
+		t.Error("Expected singleton to be cached between resolutions")
+	}
+}
+
+func TestResolveAllChanStreamsLazily(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	var constructed atomic.Int32
+	errs := &Errors{}
+	for _, name := range []string{"a", "b", "c"} {
+		errs.Add(Register[*NamedLogger](sc, AsNamed(name), WithName[LoggerEngine](name),
+			AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+				constructed.Add(1)
+				return &NamedLogger{name: name}, nil
+			})))
+	}
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	loggers, errc := ResolveAllChan[LoggerEngine](ctx, sc)
+
+	first := <-loggers
+	if first.(*NamedLogger).name != "a" {
+		t.Errorf("Expected first logger 'a', got '%s'", first.(*NamedLogger).name)
+	}
+
+	// The producer constructs at most one instance ahead of the consumer
+	if count := constructed.Load(); count > 2 {
+		t.Errorf("Expected lazy construction, got %d constructed instances", count)
+	}
+
+	names := []string{"a"}
+	for logger := range loggers {
+		names = append(names, logger.(*NamedLogger).name)
+	}
+
+	if err := <-errc; err != nil {
+		t.Fatalf("Failed to stream loggers: %v", err)
+	}
+
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("Expected loggers a,b,c, got %v", names)
+	}
+}
+
+func TestResolveAllChanStopsOnCancellation(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	errs := &Errors{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		errs.Add(Register[*NamedLogger](sc, AsNamed(name), WithName[LoggerEngine](name), namedLoggerFactory(name)))
+	}
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	loggers, errc := ResolveAllChan[LoggerEngine](ctx, sc)
+	<-loggers
+	cancel()
+
+	received := 1
+	for range loggers {
+		received++
+	}
+
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if received == 4 {
+		t.Error("Expected production to stop before all loggers were streamed")
+	}
+}
+
+func TestResolveAllChanReportsFailure(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	failure := errors.New("connection refused")
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, AsNamed("a"), WithName[LoggerEngine]("a"), namedLoggerFactory("a")))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("b"), WithName[LoggerEngine]("b"),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			return nil, failure
+		})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	loggers, errc := ResolveAllChan[LoggerEngine](ctx, sc)
+
+	received := 0
+	for range loggers {
+		received++
+	}
+
+	if err := <-errc; !errors.Is(err, failure) {
+		t.Errorf("Expected factory error, got %v", err)
+	}
+
+	if received != 1 {
+		t.Errorf("Expected 1 logger before the failure, got %d", received)
+	}
+}
+
+func TestContainerHierarchy(t *testing.T) {
+	root := NewServiceContainer()
+	child := root.CreateChild()
+	scope := child.CreateScope(t.Context())
+	defer scope.Close(t.Context())
+
+	if root.Parent() != nil || root.Root() != root {
+		t.Error("Expected root container to have no parent and be its own root")
+	}
+
+	if child.Parent() != root || scope.Parent() != child {
+		t.Error("Expected parents to follow the chain of created containers")
+	}
+
+	if scope.Root() != root || child.Root() != root {
+		t.Error("Expected every container to share the same root")
+	}
+
+	if err := Register[*NamedLogger](scope.Root(), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*NamedLogger](t.Context(), child); err != nil {
+		t.Errorf("Expected registration on the root to be visible to children, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package container
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+)
+
+// RegistrationInfo is a read-only snapshot of the metadata of a service registration.
+// It is returned by Lookup and never exposes the container's internal state.
+type RegistrationInfo struct {
+	// Name is the name of the registration
+	Name string
+
+	// Type is the concrete type the service was registered with
+	Type reflect.Type
+
+	// IsSingleton indicates whether the service is created once and cached
+	IsSingleton bool
+
+	// FabricTags indicates whether instances are created by the fabric tag factory
+	FabricTags bool
+
+	// Interfaces maps interface types to the names the service is registered under
+	Interfaces map[reflect.Type][]string
+
+	// Instantiated indicates whether a singleton instance has already been created
+	Instantiated bool
+
+	// Metadata contains the key/value pairs attached via WithMetadata
+	Metadata map[string]string
+}
+
+// Lookup returns the registration metadata for type T with the given name, taking
+// aliases and parent containers into account. Unlike Resolve, it never constructs
+// an instance. The returned info is a copy and can not be used to modify the
+// registration.
+//
+// Example:
+//
+//	if info, ok := Lookup[Database](container, "pg"); ok && info.IsSingleton {
+//		// Eagerly initialize singleton databases
+//	}
+func Lookup[T any](sc *ServiceContainer, name string) (*RegistrationInfo, bool) {
+	return sc.lookupInfo(typeKey[T](), name)
+}
+
+// lookupInfo creates the registration info for the provided type and name.
+func (sc *ServiceContainer) lookupInfo(key reflect.Type, name string) (*RegistrationInfo, bool) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	resolvedName := sc.resolveAlias(key, name)
+	service, exists := sc.services[key][resolvedName]
+	if !exists {
+		if sc.parent != nil {
+			return sc.parent.lookupInfo(key, name)
+		}
+		return nil, false
+	}
+
+	return newRegistrationInfo(service, sc.hasSingleton(service)), true
+}
+
+// Registrations returns the registration info of every service registered directly
+// in this container, ordered by type and name. Registrations inherited from parent
+// containers are not included, and neither are the default registrations of every
+// container, *Lifecycle and ContainerInfo, which remain resolvable. A registration is
+// reported as instantiated if a singleton instance has been cached for it.
+//
+// Example:
+//
+//	for _, info := range container.Registrations() {
+//		fmt.Printf("%s (%s): instantiated=%t\n", info.Type, info.Name, info.Instantiated)
+//	}
+func (sc *ServiceContainer) Registrations() []RegistrationInfo {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	result := make([]RegistrationInfo, 0)
+	for _, root := range sc.sortedRegistrations() {
+		service := sc.services[root.Type][root.Name]
+
+		result = append(result, *newRegistrationInfo(service, sc.hasSingleton(service)))
+	}
+
+	return result
+}
+
+// FindByMetadata returns the concrete types of all registrations in this container whose
+// metadata, attached via WithMetadata, contains the given key with the given value. Each
+// type is returned once, even if it is registered under multiple names, and the types are
+// ordered by name. Registrations inherited from parent containers are not included. The
+// metadata of a single registration can be inspected via Lookup.
+//
+// Example:
+//
+//	for _, t := range container.FindByMetadata("format", "json") {
+//		fmt.Printf("%s exports json\n", t)
+//	}
+func (sc *ServiceContainer) FindByMetadata(key, value string) []reflect.Type {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	seen := make(map[reflect.Type]bool)
+	result := make([]reflect.Type, 0)
+	for _, root := range sc.sortedRegistrations() {
+		service := sc.services[root.Type][root.Name]
+		if actual, ok := service.Metadata[key]; !ok || actual != value || seen[service.Type] {
+			continue
+		}
+
+		seen[service.Type] = true
+		result = append(result, service.Type)
+	}
+
+	return result
+}
+
+// IsInstantiated reports whether a singleton instance of type T with the given name
+// is currently cached in the container. It never constructs an instance and only
+// requires a read lock, making it safe to call from health checks.
+//
+// Example:
+//
+//	if !IsInstantiated[*SearchIndex](container, "") {
+//		// The index has not been warmed up yet
+//	}
+func IsInstantiated[T any](sc *ServiceContainer, name string) bool {
+	key := typeKey[T]()
+
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	service, registered := sc.services[key][sc.resolveAlias(key, name)]
+	if !registered {
+		return false
+	}
+
+	return sc.hasSingleton(service)
+}
+
+// ForEachSingleton calls fn for every singleton currently instantiated in this container,
+// passing the concrete type and name of its registration, ordered by type and name. Within
+// a scope, the scoped instances cached by the scope are visited instead. Registrations
+// whose singleton has not been created yet are not visited, and no instances are created.
+//
+// The instances are snapshotted under a read lock before fn is called, so fn may safely
+// use the container. Errors returned by fn do not stop the iteration and are returned as
+// a single aggregated error. This is useful for shutdown hooks, flushing buffers or
+// dumping diagnostic state.
+//
+// Example:
+//
+//	err := container.ForEachSingleton(func(t reflect.Type, name string, instance any) error {
+//		if flusher, ok := instance.(interface{ Flush() error }); ok {
+//			return flusher.Flush()
+//		}
+//		return nil
+//	})
+func (sc *ServiceContainer) ForEachSingleton(fn func(t reflect.Type, name string, instance any) error) error {
+	type singleton struct {
+		slot     cacheSlot
+		service  *RegistrationService
+		instance any
+	}
+
+	sc.mu.RLock()
+	singletons := make([]singleton, 0, len(sc.singletons))
+	for slot, instance := range sc.singletons {
+		singletons = append(singletons, singleton{slot: slot, service: slot.service, instance: instance})
+	}
+	sc.mu.RUnlock()
+
+	sort.Slice(singletons, func(i, j int) bool {
+		a, b := singletons[i].service, singletons[j].service
+		if a.Type.String() != b.Type.String() {
+			return a.Type.String() < b.Type.String()
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return singletons[i].slot.key < singletons[j].slot.key
+	})
+
+	errs := &Errors{}
+	for _, s := range singletons {
+		if err := fn(s.service.Type, s.service.Name, s.instance); err != nil {
+			errs.Add(fmt.Errorf("failed to visit singleton '%s' with name '%s': %w", s.service.Type, s.service.Name, err))
+		}
+	}
+
+	return errs.Errors()
+}
+
+// newRegistrationInfo creates a copy of the metadata of the provided registration.
+func newRegistrationInfo(service *RegistrationService, instantiated bool) *RegistrationInfo {
+	interfaces := make(map[reflect.Type][]string, len(service.Interfaces))
+	for iface, names := range service.Interfaces {
+		interfaces[iface] = append([]string{}, names...)
+	}
+
+	return &RegistrationInfo{
+		Name:         service.Name,
+		Type:         service.Type,
+		IsSingleton:  service.IsSingleton,
+		FabricTags:   service.FabricTags,
+		Interfaces:   interfaces,
+		Instantiated: instantiated,
+		Metadata:     maps.Clone(service.Metadata),
+	}
+}
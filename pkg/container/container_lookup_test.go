@@ -0,0 +1,250 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookupRegistrationInfo(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, WithName[LoggerEngine]("console"), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	info, ok := Lookup[LoggerEngine](sc, "console")
+	if !ok {
+		t.Fatal("Expected registration to be found")
+	}
+
+	if !info.IsSingleton || info.Instantiated || info.Type != typeKey[*LoggerService]() {
+		t.Errorf("Unexpected registration info: %+v", info)
+	}
+
+	if _, err := ResolveName[LoggerEngine](ctx, sc, "console"); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	info, _ = Lookup[LoggerEngine](sc, "console")
+	if !info.Instantiated {
+		t.Error("Expected registration to be instantiated after resolution")
+	}
+
+	delete(info.Interfaces, typeKey[LoggerEngine]())
+	if info, _ := Lookup[LoggerEngine](sc, "console"); len(info.Interfaces) != 1 {
+		t.Error("Expected registration info to be a copy")
+	}
+
+	if _, ok := Lookup[EncryptEngine](sc, ""); ok {
+		t.Error("Expected lookup of unregistered type to fail")
+	}
+}
+
+func TestIsInstantiatedAndRegistrations(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine](), AsSingleton()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine](), AsSingleton()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if IsInstantiated[LoggerEngine](sc, "") {
+		t.Error("Expected logger not to be instantiated before resolution")
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if !IsInstantiated[LoggerEngine](sc, "") {
+		t.Error("Expected logger to be instantiated after resolution")
+	}
+
+	if IsInstantiated[EncryptEngine](sc, "") {
+		t.Error("Expected encrypt engine not to be instantiated")
+	}
+
+	instantiated := make(map[reflect.Type]bool)
+	for _, info := range sc.Registrations() {
+		instantiated[info.Type] = info.Instantiated
+	}
+
+	if !instantiated[typeKey[*LoggerService]()] {
+		t.Error("Expected logger registration to be reported as instantiated")
+	}
+
+	if value, ok := instantiated[typeKey[*EncryptService]()]; !ok || value {
+		t.Error("Expected encrypt registration to be reported as not instantiated")
+	}
+}
+
+func TestForEachSingleton(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, With[LoggerEngine](), AsSingleton()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine](), AsSingleton()))
+	errs.Add(Register[*Agent](sc, AsSingleton()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if _, err := Resolve[*EncryptService](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve encrypt: %v", err)
+	}
+
+	visited := make([]reflect.Type, 0)
+	err := sc.ForEachSingleton(func(t reflect.Type, name string, instance any) error {
+		visited = append(visited, t)
+
+		// Callbacks can safely use the container
+		if _, err := Resolve[*Agent](ctx, sc); err != nil {
+			return err
+		}
+		return errors.New("flush failed")
+	})
+
+	if len(visited) != 2 || visited[0] != typeKey[*EncryptService]() || visited[1] != typeKey[*NamedLogger]() {
+		t.Errorf("Expected only instantiated singletons to be visited in order, got %v", visited)
+	}
+
+	if err == nil || strings.Count(err.Error(), "flush failed") != 2 {
+		t.Errorf("Expected callback errors to be aggregated, got %v", err)
+	}
+}
+
+func TestFindByMetadata(t *testing.T) {
+	sc := NewServiceContainer()
+
+	json := map[string]string{"format": "json"}
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine](), WithMetadata(map[string]string{"format": "text"})))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("primary"), WithMetadata(json)))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("secondary"), WithMetadata(json)))
+	errs.Add(Register[*EncryptService](sc, WithMetadata(json), WithMetadata(map[string]string{"kind": "crypto"})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	found := sc.FindByMetadata("format", "json")
+	expected := []reflect.Type{typeKey[*EncryptService](), typeKey[*NamedLogger]()}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+
+	if found := sc.FindByMetadata("format", "yaml"); len(found) != 0 {
+		t.Errorf("Expected no matches, got %v", found)
+	}
+
+	info, _ := Lookup[*EncryptService](sc, "")
+	if info.Metadata["format"] != "json" || info.Metadata["kind"] != "crypto" {
+		t.Errorf("Expected merged metadata, got %v", info.Metadata)
+	}
+
+	info.Metadata["format"] = "yaml"
+	json["format"] = "yaml"
+	if found := sc.FindByMetadata("format", "json"); len(found) != 2 {
+		t.Error("Expected metadata not to be modifiable after registration")
+	}
+}
+
+func TestAsOptionalInterfaces(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*CryptoLogger](sc, AsSingleton()))
+	errs.Add(Register[*LoggerService](sc, AsSingleton()))
+	errs.Add(Register[*EncryptService](sc, AsSingleton()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	crypto, err := Resolve[*CryptoLogger](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve crypto logger: %v", err)
+	}
+
+	if logger, ok := As[LoggerEngine](crypto); !ok || logger != crypto {
+		t.Error("Expected instance to satisfy LoggerEngine")
+	}
+
+	if _, ok := As[CounterEngine](crypto); ok {
+		t.Error("Expected instance not to satisfy CounterEngine")
+	}
+
+	if _, ok := As[LoggerEngine](nil); ok {
+		t.Error("Expected nil instance not to satisfy LoggerEngine")
+	}
+
+	for _, resolve := range []func() error{
+		func() error { _, err := Resolve[*LoggerService](ctx, sc); return err },
+		func() error { _, err := Resolve[*EncryptService](ctx, sc); return err },
+	} {
+		if err := resolve(); err != nil {
+			t.Fatalf("Failed to resolve service: %v", err)
+		}
+	}
+
+	encrypters := make([]string, 0)
+	err = sc.ForEachSingleton(func(t reflect.Type, name string, instance any) error {
+		if _, ok := As[EncryptEngine](instance); ok {
+			encrypters = append(encrypters, t.String())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to visit singletons: %v", err)
+	}
+
+	if strings.Join(encrypters, ",") != "*container.CryptoLogger,*container.EncryptService" {
+		t.Errorf("Expected both encrypters to be visited, got %v", encrypters)
+	}
+}
+
+func TestDefaultRegistrationsNotEnumerated(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if registrations := sc.Registrations(); len(registrations) != 0 {
+		t.Errorf("Expected default registrations not to be listed, got %v", registrations)
+	}
+
+	all, err := ResolveAllAssignable[any](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve assignable services: %v", err)
+	}
+
+	if len(all) != 0 {
+		t.Errorf("Expected default registrations not to be discovered, got %d instances", len(all))
+	}
+
+	warnings, err := sc.ValidateWithWarnings()
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("Expected empty container to validate without warnings, got %v and %v", warnings, err)
+	}
+
+	if _, err := Resolve[*Lifecycle](ctx, sc); err != nil {
+		t.Errorf("Expected lifecycle to remain resolvable: %v", err)
+	}
+
+	if _, err := Resolve[ContainerInfo](ctx, sc); err != nil {
+		t.Errorf("Expected container info to remain resolvable: %v", err)
+	}
+}
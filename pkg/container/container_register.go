@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 )
 
 // Register registers a service of type T with the container using the provided options.
@@ -18,7 +19,14 @@ import (
 //
 // The registration process automatically detects and configures fabric tag processing
 // if the service struct contains fabric:"inject" tags, enabling automatic dependency
-// injection during service creation.
+// injection during service creation. Fields without a fabric tag are never touched by
+// any factory, even if other fields of the same struct are injected, so they remain at
+// their zero value and can safely be populated manually.
+//
+// The concrete type T is always registered under its own type (using the name set
+// via AsNamed, or the empty name), in addition to any interface mappings. This means
+// Resolve[*PostgresDB] succeeds even if the service was only registered with a named
+// interface mapping such as WithName[Database]("pg").
 //
 // Examples:
 //
@@ -45,29 +53,133 @@ import (
 //	}
 //	err = Register[*UserService](container, With[UserService]())
 func Register[T any](sc *ServiceContainer, opts ...RegistrationOption) error {
+	_, err := register[T](sc, opts...)
+	return err
+}
+
+// RegisterChecked registers a service like Register, but additionally returns the
+// metadata of the registration that was overwritten, if any. An existing registration
+// is overwritten if it used the same concrete type and name, or if one of the interface
+// mappings of the new registration was previously mapped to another registration.
+// If multiple registrations were overwritten, the one of the concrete type is returned.
+//
+// This gives visibility into accidental double registrations without changing the
+// behavior of Register, which silently overwrites existing entries.
+//
+// Example:
+//
+//	replaced, err := RegisterChecked[*PostgresDB](container, WithName[Database]("pg"))
+//	if replaced != nil {
+//		log.Printf("Registration of '%s' replaced existing '%s'", "pg", replaced.Type)
+//	}
+func RegisterChecked[T any](sc *ServiceContainer, opts ...RegistrationOption) (*RegistrationInfo, error) {
+	return register[T](sc, opts...)
+}
+
+// RegisterAll registers every provided instance as type T under the name returned by
+// nameFn, making already constructed services, such as plugins discovered via a registry,
+// resolvable by name via ResolveName and as a group via ResolveAll. Each instance is
+// registered like Register[T] with AsNamed and WithInstance, so T is usually the interface
+// the instances share. The instances and names are validated before any instance is
+// registered, and an error is returned if an instance is nil or nameFn returns the same
+// name for multiple instances. All instances are registered at once: either every instance
+// is registered, or the container is left unchanged. Existing registrations with the same
+// name are overwritten, like with Register.
+//
+// Example:
+//
+//	err := RegisterAll[Plugin](container, discovered, func(p Plugin) string {
+//		return p.Name()
+//	})
+//
+//	// Later resolve a single plugin or all of them:
+//	plugin, err := ResolveName[Plugin](ctx, container, "metrics")
+//	plugins, err := ResolveAll[Plugin](ctx, container)
+func RegisterAll[T any](sc *ServiceContainer, instances []T, nameFn func(T) string) error {
+	key := typeKey[T]()
+	if nameFn == nil {
+		return fmt.Errorf("name function for '%s' must not be nil", key)
+	}
+
+	names := make([]string, len(instances))
+	for i, instance := range instances {
+		if isNil(instance) {
+			return fmt.Errorf("instance %d of '%s': %w", i, key, ErrNilInstance)
+		}
+		names[i] = nameFn(instance)
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	seen := make(map[string]int, len(instances))
+	for i := range names {
+		name := sc.normalizeName(names[i])
+		if previous, exists := seen[name]; exists {
+			return fmt.Errorf("instances %d and %d of '%s' share the name '%s'", previous, i, key, name)
+		}
+		seen[name] = i
+	}
+
+	// Validated instances always provide a name and an instance, so registering them can not fail
+	for i, instance := range instances {
+		_, _ = registerLocked[T](sc, AsNamed(names[i]), WithInstance(instance))
+	}
+
+	return nil
+}
+
+// register stores a new registration for type T and returns the metadata of the
+// registration it overwrote, if any.
+func register[T any](sc *ServiceContainer, opts ...RegistrationOption) (*RegistrationInfo, error) {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
 
+	return registerLocked[T](sc, opts...)
+}
+
+// registerLocked stores a new registration for type T like register. The caller must
+// hold the lock.
+func registerLocked[T any](sc *ServiceContainer, opts ...RegistrationOption) (*RegistrationInfo, error) {
 	options := defaultRegistrationOptions()
 	for _, opt := range opts {
 		if err := opt(options); err != nil {
-			return fmt.Errorf("failed to complete registration: %w", err)
+			return nil, fmt.Errorf("failed to complete registration: %w", err)
+		}
+	}
+
+	if err := validateInterfaces[T](options); err != nil {
+		return nil, fmt.Errorf("failed to validate interface mappings: %w", err)
+	}
+
+	options.Name = sc.normalizeName(options.Name)
+	for ifaceType, names := range options.Interfaces {
+		for i, name := range names {
+			options.Interfaces[ifaceType][i] = sc.normalizeName(name)
 		}
 	}
 
 	// If no factory is provided, create one automatically
 	if options.Factory == nil {
-		if hasFabricTags[T]() {
+		if key := typeKey[T](); key.Kind() == reflect.Interface {
+			return nil, fmt.Errorf("can not register interface type '%s' without a factory or instance, register a concrete type using With[%s]() instead", key, key)
+		}
+
+		if !options.DisableTagProcessing && hasFabricTags[T]() {
 			ok, err := validateFabricTags[T](sc)
 			if err != nil {
-				return fmt.Errorf("failed to validate fabric tags: %w", err)
+				return nil, fmt.Errorf("failed to validate fabric tags: %w", err)
 			}
 
 			if !ok {
-				return fmt.Errorf("no valid factory found during registration")
+				return nil, fmt.Errorf("no valid factory found during registration")
 			}
 
 			options.Factory = createFabricTagFactory[T]()
+			if factory, exists := generatedFactory(typeKey[T]()); exists && !options.DisableGeneratedFactory {
+				options.Factory = factory
+			}
+			options.FabricTags = true
 		} else {
 			// Default factory - create instance using Go's zero value constructor
 			options.Factory = func(ctx context.Context, sc *ServiceContainer) (any, error) {
@@ -90,14 +202,36 @@ func Register[T any](sc *ServiceContainer, opts ...RegistrationOption) error {
 		}
 	}
 
+	options.Type = typeKey[T]()
+	if processesCacheHits(options.Middlewares) {
+		sc.cacheHitMiddlewares.Store(true)
+	}
+
+	// Registrations made with the staging container of a reloaded module are recorded
+	if sc.recorder != nil {
+		sc.recorder.services = append(sc.recorder.services, options)
+	}
+
+	return sc.storeRegistration(options), nil
+}
+
+// storeRegistration stores the registration under its concrete type and all of its
+// interface mappings and returns the metadata of the registration it overwrote, if any.
+// The caller must hold the lock.
+func (sc *ServiceContainer) storeRegistration(options *RegistrationService) *RegistrationInfo {
 	// Register the concrete type
-	concreteKey := typeKey[T]()
+	concreteKey := options.Type
 	maps, exists := sc.services[concreteKey]
 	if !exists {
 		maps = make(map[string]*RegistrationService)
 		sc.services[concreteKey] = maps
 	}
+	var replaced *RegistrationInfo
+	if previous, exists := maps[options.Name]; exists {
+		replaced = sc.replacedInfo(previous)
+	}
 	maps[options.Name] = options
+	sc.instances.Delete(instanceKey{key: concreteKey, name: options.Name})
 
 	// Register all interface mappings
 	for ifaceType, names := range options.Interfaces {
@@ -108,9 +242,142 @@ func Register[T any](sc *ServiceContainer, opts ...RegistrationOption) error {
 		}
 
 		for _, name := range names {
+			previous, exists := ifaceMaps[name]
+			sc.instances.Delete(instanceKey{key: ifaceType, name: name})
+
+			// Prioritized registrations compete for unnamed mappings, keeping the loser available to ResolveAll
+			if exists && name == "" && (previous.Prioritized || options.Prioritized) {
+				if previous.Priority > options.Priority {
+					sc.contenders[ifaceType] = append(sc.contenders[ifaceType], options)
+					continue
+				}
+
+				sc.contenders[ifaceType] = append(sc.contenders[ifaceType], previous)
+				ifaceMaps[name] = options
+				continue
+			}
+
+			if exists && replaced == nil {
+				replaced = sc.replacedInfo(previous)
+			}
 			ifaceMaps[name] = options
 		}
 	}
 
+	return replaced
+}
+
+// validateInterfaces verifies that the concrete type T implements every interface
+// it is mapped to via With[I]() or WithName[I](name), and that T is not mapped to itself. If T does not implement an
+// interface but *T does, the returned error points out the pointer receiver, since
+// registering a value type for an interface implemented by pointer methods is a
+// common mistake that would otherwise only surface as a cast failure during resolution.
+func validateInterfaces[T any](options *RegistrationService) error {
+	concrete := typeKey[T]()
+
+	for ifaceType := range options.Interfaces {
+		if ifaceType == concrete {
+			return fmt.Errorf("type '%s' can not be mapped to itself, the concrete type is always registered under its own type", concrete)
+		}
+
+		if ifaceType.Kind() != reflect.Interface {
+			continue
+		}
+
+		if concrete.Implements(ifaceType) {
+			continue
+		}
+
+		if concrete.Kind() != reflect.Ptr && reflect.PointerTo(concrete).Implements(ifaceType) {
+			return fmt.Errorf("type '%s' does not implement '%s' (methods have pointer receiver), register '%s' instead",
+				concrete, ifaceType, reflect.PointerTo(concrete))
+		}
+
+		return fmt.Errorf("type '%s' does not implement '%s'", concrete, ifaceType)
+	}
+
+	return nil
+}
+
+// replacedInfo creates the registration info of an overwritten registration.
+// The caller must hold the lock.
+func (sc *ServiceContainer) replacedInfo(previous *RegistrationService) *RegistrationInfo {
+	return newRegistrationInfo(previous, sc.hasSingleton(previous))
+}
+
+// BindType maps the interface type under the given name to an existing registration of
+// the concrete type with the concrete name, equivalent to registering the concrete type
+// with WithName[I](name), but using runtime types. This allows re-wiring registered
+// implementations at boot without recompiling, e.g. from a wiring manifest. Existing
+// mappings of the interface and name are replaced, while the instances cached for their
+// registrations are kept.
+//
+// Example:
+//
+//	err := container.BindType(reflect.TypeOf((*Cache)(nil)).Elem(), "", reflect.TypeOf(&RedisCache{}), "")
+func (sc *ServiceContainer) BindType(iface reflect.Type, name string, concrete reflect.Type, concreteName string) error {
+	if iface.Kind() != reflect.Interface {
+		return fmt.Errorf("can not bind '%s': not an interface type", iface)
+	}
+
+	if !concrete.Implements(iface) {
+		return fmt.Errorf("type '%s' does not implement interface '%s'", concrete, iface)
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	name, concreteName = sc.normalizeName(name), sc.normalizeName(concreteName)
+
+	service, exists := sc.services[concrete][concreteName]
+	if !exists {
+		return fmt.Errorf("registration for '%s' and name '%s': %w", concrete, concreteName, ErrNotRegistered)
+	}
+
+	ifaceMaps, exists := sc.services[iface]
+	if !exists {
+		ifaceMaps = make(map[string]*RegistrationService)
+		sc.services[iface] = ifaceMaps
+	}
+	if previous, exists := ifaceMaps[name]; exists {
+		if previous == service {
+			return nil
+		}
+		previous.Interfaces[iface] = slices.DeleteFunc(previous.Interfaces[iface], func(n string) bool {
+			return n == name
+		})
+	}
+
+	ifaceMaps[name] = service
+	service.Interfaces[iface] = append(service.Interfaces[iface], name)
+
+	sc.instances.Delete(instanceKey{key: iface, name: name})
+
+	return nil
+}
+
+// Bind exposes the existing unnamed registration of From under the interface To with
+// the provided name, without registering the concrete type again. Like BindType, the
+// mapping points at the registration of From itself, so resolving To shares its
+// singleton or scoped instance, settings and middlewares, and the registration is
+// initialized, listed and cleaned up only once. This allows modules to incrementally
+// expose services registered by other modules under further interfaces.
+//
+// An error is returned if From is not registered in this container or does not
+// implement To.
+//
+// Example:
+//
+//	// package logging
+//	err := Register[*Logger](container, AsSingleton())
+//
+//	// package audit
+//	err = Bind[*Logger, io.Writer](container, "audit")
+func Bind[From, To any](sc *ServiceContainer, name string) error {
+	from, to := typeKey[From](), typeKey[To]()
+	if err := sc.BindType(to, name, from, ""); err != nil {
+		return fmt.Errorf("failed to bind '%s' to '%s': %w", from, to, err)
+	}
+
 	return nil
 }
@@ -0,0 +1,457 @@
+package container
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterInterfaceValidation(t *testing.T) {
+	sc := NewServiceContainer()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	err := Register[LoggerService](sc, With[LoggerEngine]())
+	if err == nil {
+		t.Fatal("Expected registration of value type with pointer receiver methods to fail")
+	}
+	if !strings.Contains(err.Error(), "pointer receiver") {
+		t.Errorf("Expected error to mention pointer receiver, got %v", err)
+	}
+
+	if err := Register[*LoggerService](sc, WithName[EncryptEngine]("encrypt")); err == nil {
+		t.Error("Expected registration with unimplemented interface to fail")
+	}
+}
+
+type CacheService struct {
+	entries map[string]string
+}
+
+func TestRegisterConcreteTypeWithMultipleLifetimes(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*CacheService](sc, AsSingleton()))
+	errs.Add(Register[*CacheService](sc, AsNamed("fresh")))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	shared1, err := Resolve[*CacheService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve singleton: %v", err)
+	}
+	shared2, err := Resolve[*CacheService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve singleton: %v", err)
+	}
+	if shared1 != shared2 {
+		t.Error("Expected singleton resolutions to return the same instance")
+	}
+
+	fresh1, err := ResolveName[*CacheService](ctx, sc, "fresh")
+	if err != nil {
+		t.Fatalf("Failed to resolve transient: %v", err)
+	}
+	fresh2, err := ResolveName[*CacheService](ctx, sc, "fresh")
+	if err != nil {
+		t.Fatalf("Failed to resolve transient: %v", err)
+	}
+	if fresh1 == fresh2 || fresh1 == shared1 {
+		t.Error("Expected transient resolutions to return new instances")
+	}
+}
+
+type User struct{ ID string }
+
+type Order struct{ ID string }
+
+type Repository[T any] struct {
+	Logger LoggerEngine `fabric:"inject"`
+	items  []T
+}
+
+func TestRegisterGenericServiceTypes(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*Repository[User]](sc, AsSingleton()))
+	errs.Add(Register[*Repository[Order]](sc, AsSingleton()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	users, err := Resolve[*Repository[User]](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve user repository: %v", err)
+	}
+
+	orders, err := Resolve[*Repository[Order]](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve order repository: %v", err)
+	}
+
+	if users.Logger == nil || orders.Logger == nil {
+		t.Error("Logger was not successfully injected into generic repositories")
+	}
+
+	users.items = append(users.items, User{ID: "1"})
+	if len(orders.items) != 0 {
+		t.Error("Expected generic instantiations to be distinct registrations")
+	}
+}
+
+func TestRegisterInterfaceWithoutFactory(t *testing.T) {
+	sc := NewServiceContainer()
+
+	if err := Register[LoggerEngine](sc); err == nil {
+		t.Error("Expected registration of interface type without factory to fail")
+	}
+
+	if err := Register[LoggerEngine](sc, WithInstance(&LoggerService{})); err != nil {
+		t.Errorf("Expected registration of interface type with instance to succeed, got %v", err)
+	}
+}
+
+func TestRegisterSelfMapping(t *testing.T) {
+	sc := NewServiceContainer()
+
+	if err := Register[*LoggerService](sc, With[*LoggerService]()); err == nil {
+		t.Error("Expected concrete type mapped to itself to fail")
+	}
+
+	if err := Register[LoggerEngine](sc, WithInstance(&LoggerService{}), WithName[LoggerEngine]("self")); err == nil {
+		t.Error("Expected interface type mapped to itself to fail")
+	}
+
+	if err := Register[*EncryptService](sc, With[LoggerEngine]()); err == nil {
+		t.Error("Expected mapping to unimplemented interface to fail")
+	}
+}
+
+func TestRegisterCheckedReportsReplacement(t *testing.T) {
+	sc := NewServiceContainer()
+
+	replaced, err := RegisterChecked[*LoggerService](sc, WithName[LoggerEngine]("console"), AsSingleton())
+	if err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if replaced != nil {
+		t.Errorf("Expected no replaced registration, got %+v", replaced)
+	}
+
+	replaced, err = RegisterChecked[*NamedLogger](sc, WithName[LoggerEngine]("console"))
+	if err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if replaced == nil || replaced.Type != typeKey[*LoggerService]() || !replaced.IsSingleton {
+		t.Errorf("Expected replaced singleton logger registration, got %+v", replaced)
+	}
+
+	replaced, err = RegisterChecked[*NamedLogger](sc)
+	if err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if replaced == nil || replaced.Type != typeKey[*NamedLogger]() {
+		t.Errorf("Expected replaced concrete registration, got %+v", replaced)
+	}
+}
+
+func TestWithPriorityResolvesHighestPriority(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine](), WithPriority(20)))
+	errs.Add(Register[*NamedLogger](sc, With[LoggerEngine](), WithPriority(10), namedLoggerFactory("low")))
+	errs.Add(Register[*StubLogger](sc, With[LoggerEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	logger, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if _, ok := logger.(*LoggerService); !ok {
+		t.Errorf("Expected highest priority logger, got %T", logger)
+	}
+
+	loggers, err := ResolveAll[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve loggers: %v", err)
+	}
+
+	if len(loggers) != 3 {
+		t.Fatalf("Expected all 3 loggers to remain available, got %d", len(loggers))
+	}
+
+	if _, ok := loggers[1].(*NamedLogger); !ok {
+		t.Errorf("Expected contenders ordered by priority, got %T", loggers[1])
+	}
+}
+
+func TestBindTypeRewiresInterface(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine](), AsSingleton()))
+	errs.Add(Register[*StubLogger](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if err := sc.BindType(typeKey[LoggerEngine](), "", typeKey[*StubLogger](), ""); err != nil {
+		t.Fatalf("Failed to bind logger: %v", err)
+	}
+
+	logger, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if _, ok := logger.(*StubLogger); !ok {
+		t.Errorf("Expected re-wired logger, got %T", logger)
+	}
+
+	if err := sc.BindType(typeKey[LoggerEngine](), "", typeKey[*NamedLogger](), ""); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected binding of unregistered type to fail with ErrNotRegistered, got %v", err)
+	}
+}
+
+func TestBindSharesSingleton(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*NamedLogger](sc, AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := Bind[*NamedLogger, LoggerEngine](sc, "audit"); err != nil {
+		t.Fatalf("Failed to bind logger: %v", err)
+	}
+
+	logger, err := Resolve[*NamedLogger](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	bound, err := ResolveName[LoggerEngine](ctx, sc, "audit")
+	if err != nil {
+		t.Fatalf("Failed to resolve bound logger: %v", err)
+	}
+
+	if bound != LoggerEngine(logger) {
+		t.Error("Expected bound interface to share the singleton instance")
+	}
+}
+
+func TestBindReusesRegistration(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	order := make([]string, 0)
+	sc.AddMiddleware(&recordingMiddleware{name: "global", order: &order})
+
+	if err := Register[*NamedLogger](sc, AsSingleton(), namedLoggerFactory("bound")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := Bind[*NamedLogger, LoggerEngine](sc, "audit"); err != nil {
+		t.Fatalf("Failed to bind logger: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := ResolveName[LoggerEngine](ctx, sc, "audit"); err != nil {
+			t.Fatalf("Failed to resolve bound logger: %v", err)
+		}
+	}
+
+	if len(order) != 1 {
+		t.Errorf("Expected global middleware to run once for the bound singleton, got %d", len(order))
+	}
+
+	loggers := 0
+	for _, info := range sc.Registrations() {
+		if info.Type == typeKey[*NamedLogger]() || info.Type == typeKey[LoggerEngine]() {
+			loggers++
+		}
+	}
+
+	if loggers != 1 {
+		t.Errorf("Expected bound interface not to add a registration, got %d", loggers)
+	}
+
+	info, ok := Lookup[LoggerEngine](sc, "audit")
+	if !ok || info.Type != typeKey[*NamedLogger]() || !info.IsSingleton || !info.Instantiated {
+		t.Errorf("Expected lookup of the bound interface to describe the original registration, got %+v", info)
+	}
+
+	if !IsInstantiated[*NamedLogger](sc, "") {
+		t.Error("Expected original registration to be instantiated via the bound interface")
+	}
+}
+
+func TestBindValidation(t *testing.T) {
+	sc := NewServiceContainer()
+
+	if err := Bind[*NamedLogger, LoggerEngine](sc, ""); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected binding of unregistered type to fail with ErrNotRegistered, got %v", err)
+	}
+
+	if err := Register[*NamedLogger](sc); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := Bind[*NamedLogger, EncryptEngine](sc, ""); err == nil || !strings.Contains(err.Error(), "does not implement") {
+		t.Errorf("Expected binding to unimplemented interface to fail, got %v", err)
+	}
+}
+
+func TestRegisterAll(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	plugins := []LoggerEngine{
+		&NamedLogger{name: "audit"},
+		&NamedLogger{name: "metrics"},
+		&NamedLogger{name: "trace"},
+	}
+
+	nameOf := func(l LoggerEngine) string {
+		return l.(*NamedLogger).name
+	}
+
+	if err := RegisterAll(sc, plugins, nameOf); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for _, plugin := range plugins {
+		resolved, err := ResolveName[LoggerEngine](ctx, sc, nameOf(plugin))
+		if err != nil {
+			t.Fatalf("Failed to resolve plugin '%s': %v", nameOf(plugin), err)
+		}
+
+		if resolved != plugin {
+			t.Errorf("Expected plugin '%s' to resolve to the registered instance", nameOf(plugin))
+		}
+	}
+
+	all, err := ResolveAll[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve all plugins: %v", err)
+	}
+
+	if len(all) != len(plugins) {
+		t.Fatalf("Expected %d plugins, got %d", len(plugins), len(all))
+	}
+
+	for i := range plugins {
+		if all[i] != plugins[i] {
+			t.Errorf("Expected plugin %d to be '%s', got '%s'", i, nameOf(plugins[i]), nameOf(all[i]))
+		}
+	}
+}
+
+func TestRegisterAllDuplicateNames(t *testing.T) {
+	sc := NewServiceContainer()
+
+	plugins := []LoggerEngine{
+		&NamedLogger{name: "audit"},
+		&NamedLogger{name: "audit"},
+	}
+
+	err := RegisterAll(sc, plugins, func(l LoggerEngine) string {
+		return l.(*NamedLogger).name
+	})
+	if err == nil || !strings.Contains(err.Error(), "share the name 'audit'") {
+		t.Fatalf("Expected duplicate names to be rejected, got %v", err)
+	}
+
+	if names := sc.registrationNames(typeKey[LoggerEngine]()); len(names) != 0 {
+		t.Errorf("Expected no instance to be registered after a failed validation, got %v", names)
+	}
+}
+
+func TestRegisterAllNilInstance(t *testing.T) {
+	sc := NewServiceContainer()
+
+	var missing *NamedLogger
+	plugins := []LoggerEngine{
+		&NamedLogger{name: "audit"},
+		missing,
+		&NamedLogger{name: "trace"},
+	}
+
+	err := RegisterAll(sc, plugins, func(l LoggerEngine) string {
+		if logger, ok := l.(*NamedLogger); ok && logger != nil {
+			return logger.name
+		}
+		return "missing"
+	})
+	if !errors.Is(err, ErrNilInstance) {
+		t.Fatalf("Expected nil instance to be rejected with ErrNilInstance, got %v", err)
+	}
+
+	if names := sc.registrationNames(typeKey[LoggerEngine]()); len(names) != 0 {
+		t.Errorf("Expected no instance to be registered after a failed call, got %v", names)
+	}
+}
+
+func TestRegisterAllFailureLeavesContainerUnchanged(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+	sc.SetNameNormalizer(strings.ToLower)
+
+	existing := &NamedLogger{name: "metrics"}
+	if err := Register[*NamedLogger](sc, WithInstance(existing), WithName[LoggerEngine]("metrics")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	// The names only collide once normalized, after the preceding instances have been validated
+	plugins := []LoggerEngine{
+		&NamedLogger{name: "trace"},
+		&NamedLogger{name: "metrics"},
+		&NamedLogger{name: "Audit"},
+		&NamedLogger{name: "audit"},
+	}
+
+	err := RegisterAll(sc, plugins, func(l LoggerEngine) string {
+		return l.(*NamedLogger).name
+	})
+	if err == nil || !strings.Contains(err.Error(), "instances 2 and 3") {
+		t.Fatalf("Expected names colliding after normalization to be rejected, got %v", err)
+	}
+
+	if names := sc.registrationNames(typeKey[LoggerEngine]()); len(names) != 1 || names[0] != "metrics" {
+		t.Errorf("Expected only the existing registration to remain, got %v", names)
+	}
+
+	resolved, err := ResolveName[LoggerEngine](ctx, sc, "metrics")
+	if err != nil {
+		t.Fatalf("Failed to resolve existing registration: %v", err)
+	}
+
+	if resolved != existing {
+		t.Error("Expected existing registration not to be replaced by a failed call")
+	}
+}
@@ -2,7 +2,11 @@ package container
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"runtime/debug"
+	"time"
 )
 
 // ResolveName resolves a service of type T with the specified name from the container.
@@ -13,7 +17,7 @@ import (
 //  1. Looks up the service registration by type and name
 //  2. For singletons, checks if an instance already exists and returns it
 //  3. Otherwise, calls the service's factory function to create a new instance
-//  4. Applies any registered middlewares to the instance
+//  4. Applies global middlewares, followed by registration-specific middlewares and decorators
 //  5. Runs lifecycle initialization if the service implements LifecycleService
 //  6. For singletons, caches the instance for future resolutions
 //
@@ -27,84 +31,528 @@ import (
 //	pgDB, err := ResolveName[Database](ctx, container, "postgres")
 //	myDB, err := ResolveName[Database](ctx, container, "mysql")
 func ResolveName[T any](ctx context.Context, sc *ServiceContainer, name string) (T, error) {
-	var zero T
 	key := typeKey[T]()
+	if instance, ok := sc.cachedInstance(ctx, key, name); ok {
+		if typed, ok := instance.(T); ok {
+			return typed, nil
+		}
+	}
+
+	var typed T
+
+	// The cast is part of the session, so instances of a failed resolution are not kept for cleanup
+	err := sc.withSession(ctx, func(ctx context.Context) error {
+		resolved, err := sc.resolve(ctx, key, name)
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		typed, ok = resolved.(T)
+		if !ok {
+			return fmt.Errorf("resolved value of type '%T' is not assignable to '%s'", resolved, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return typed, nil
+}
+
+// resolve runs the full resolution pipeline for the service registered with the
+// given type and name. Every resolution entry point (Resolve, ResolveName,
+// ResolveByType and the inject tag processor) uses this pipeline, ensuring that
+// services behave identically regardless of how they are reached. The context is
+// checked for cancellation between each stage, aborting resolution early:
+//  1. Returns the cached instance for singletons that have already been created
+//  2. Otherwise, calls the service's factory function to create a new instance
+//  3. Applies global middlewares, followed by registration-specific middlewares and decorators
+//  4. Runs lifecycle initialization if the service implements LifecycleService
+//  5. For singletons, caches the instance for future resolutions
+//
+// Fields marked for late injection (fabric:"inject,late") are populated once the
+// outermost resolution has completed, after all involved services have been constructed.
+func (sc *ServiceContainer) resolve(ctx context.Context, key reflect.Type, name string) (any, error) {
+	// Containers created by WithOverrides apply their overrides to the whole dependency graph
+	if len(sc.overrides) > 0 && ctx.Value(overrideContextKey{}) == nil {
+		ctx = context.WithValue(ctx, overrideContextKey{}, sc)
+	}
+
+	if instance, ok := sc.cachedInstance(ctx, key, name); ok {
+		return instance, nil
+	}
+
+	var instance any
+	err := sc.withSession(ctx, func(ctx context.Context) error {
+		var err error
+		instance, err = sc.resolveInstance(ctx, key, name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// instanceKey identifies a cached instance by its type and registration name.
+type instanceKey struct {
+	key  reflect.Type
+	name string
+}
+
+// cachedInstance returns an already constructed singleton or scoped instance with a
+// single lock-free lookup, bypassing the resolution pipeline entirely. Resolutions with
+// overrides, fresh instances, recorded graphs or a done context always take the full
+// pipeline, as do names that are aliases or not yet normalized, since only registration
+// names are cached.
+func (sc *ServiceContainer) cachedInstance(ctx context.Context, key reflect.Type, name string) (any, bool) {
+	if sc.cacheHitMiddlewares.Load() {
+		return nil, false
+	}
+
+	instance, ok := sc.instances.Load(instanceKey{key: key, name: name})
+	if !ok || ctx.Err() != nil {
+		return nil, false
+	}
+
+	if ctx.Value(overridesContextKey{}) != nil || ctx.Value(graphContextKey{}) != nil || freshInstances(ctx) {
+		return nil, false
+	}
+
+	if _, seamed := overrideSeam(ctx, sc); seamed {
+		return nil, false
+	}
+
+	return instance, true
+}
+
+// cacheSlot identifies a cached singleton by its registration and the cache key chosen via
+// WithCacheKey, which is empty for regular singletons.
+type cacheSlot struct {
+	service *RegistrationService
+	key     string
+}
+
+// viewSlot identifies the view of a cached singleton for the type and name it was resolved with.
+type viewSlot struct {
+	slot cacheSlot
+	key  reflect.Type
+	name string
+}
+
+// hasSingleton reports whether an instance of the provided registration is cached for any
+// cache key. The caller must hold the lock.
+func (sc *ServiceContainer) hasSingleton(service *RegistrationService) bool {
+	if _, exists := sc.singletons[cacheSlot{service: service}]; exists || service.CacheKey == nil {
+		return exists
+	}
+
+	for slot := range sc.singletons {
+		if slot.service == service {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evict removes the cached instance of the provided slot, so the next resolution
+// constructs a new instance.
+func (sc *ServiceContainer) evict(key reflect.Type, name string, slot cacheSlot) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	delete(sc.singletons, slot)
+	for view := range sc.views {
+		if view.slot == slot {
+			delete(sc.views, view)
+			sc.instances.Delete(instanceKey{key: view.key, name: view.name})
+		}
+	}
+	sc.instances.Delete(instanceKey{key: key, name: name})
+}
+
+// cacheView processes the cached singleton of the provided slot with the middlewares and
+// decorators of the requested type and name, and caches the result for later resolutions.
+// The singleton itself is shared by all types and names mapped to its registration.
+func (sc *ServiceContainer) cacheView(ctx context.Context, key reflect.Type, name string, slot cacheSlot, singleton any, overridden bool) (any, error) {
+	instance, err := sc.processMiddlewares(ctx, key, name, slot.service, singleton, false)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err = sc.decorate(ctx, key, name, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	// Views created with overrides are never cached to leave the container untouched
+	if overridden {
+		return instance, nil
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	view := viewSlot{slot: slot, key: key, name: name}
+	if existing, exists := sc.views[view]; exists {
+		return existing, nil
+	}
+
+	// The singleton may have been evicted or removed in the meantime
+	if _, exists := sc.singletons[slot]; exists {
+		sc.storeView(view, instance)
+	}
+
+	return instance, nil
+}
+
+// adoptSingleton returns the view of the singleton cached for the provided slot by a
+// concurrent resolution for the requested type and name, creating it if necessary. The
+// instance constructed by this resolution is discarded.
+func (sc *ServiceContainer) adoptSingleton(ctx context.Context, key reflect.Type, name string, slot cacheSlot, constructed, singleton any, overridden bool) (any, error) {
+	if err := sc.discardInstance(ctx, constructed, singleton); err != nil {
+		return nil, err
+	}
 
 	sc.mu.RLock()
-	serviceMaps, exists := sc.services[key]
+	view, viewed := sc.views[viewSlot{slot: slot, key: key, name: name}]
 	sc.mu.RUnlock()
 
-	if !exists {
-		return zero, fmt.Errorf("registration for '%T' not found", zero)
+	if viewed {
+		return view, nil
 	}
 
-	service, exists := serviceMaps[name]
-	if !exists {
-		return zero, fmt.Errorf("registration for '%T' and name '%s' not found", zero, name)
+	return sc.cacheView(ctx, key, name, slot, singleton, overridden)
+}
+
+// discardInstance cleans up an instance constructed by a resolution that does not return it,
+// such as one that lost the race for a singleton against another resolution. Instances shared with the kept singleton or already
+// managed by the container, such as pre-created instances, are left untouched.
+func (sc *ServiceContainer) discardInstance(ctx context.Context, instance, kept any) error {
+	lifecycle, ok := instance.(LifecycleService)
+	if !ok || !reflect.TypeOf(instance).Comparable() || instance == kept || sc.isManaged(instance) {
+		return nil
+	}
+
+	if err := lifecycle.Cleanup(ctx); err != nil {
+		return fmt.Errorf("failed to cleanup discarded '%T': %w", lifecycle, err)
+	}
+
+	return nil
+}
+
+// abortConstruction discards an instance constructed by a resolution that is aborted before
+// returning it, and returns the provided error along with any cleanup error. Since the
+// resolution may have been aborted by its context, cleanup runs without its cancellation.
+func (sc *ServiceContainer) abortConstruction(ctx context.Context, instance any, err error) error {
+	if cleanupErr := sc.discardInstance(context.WithoutCancel(ctx), instance, nil); cleanupErr != nil {
+		return errors.Join(err, cleanupErr)
+	}
+
+	return err
+}
+
+// storeView caches the view of a singleton, and makes it available to the lock-free
+// fast path unless it depends on the context via WithCacheKey. The caller must hold the lock.
+func (sc *ServiceContainer) storeView(view viewSlot, instance any) {
+	sc.views[view] = instance
+	if view.slot.service.CacheKey == nil {
+		sc.instances.Store(instanceKey{key: view.key, name: view.name}, instance)
+	}
+}
+
+// resolveInstance runs the resolution pipeline within the current resolution session,
+// recovering panics if enabled via SetRecoverPanics.
+func (sc *ServiceContainer) resolveInstance(ctx context.Context, key reflect.Type, name string) (any, error) {
+	sc.mu.RLock()
+	recoverPanics := sc.recoverPanics
+	sc.mu.RUnlock()
+
+	if recoverPanics {
+		return sc.resolveRecovering(ctx, key, name)
 	}
 
-	if service.IsSingleton {
+	return sc.resolveUnguarded(ctx, key, name)
+}
+
+// resolveRecovering runs the resolution pipeline and converts a panic raised by a
+// factory, middleware, decorator or lifecycle into an error naming the service.
+func (sc *ServiceContainer) resolveRecovering(ctx context.Context, key reflect.Type, name string) (instance any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			instance = nil
+			err = fmt.Errorf("%w for '%s' and name '%s': %v\n%s", ErrPanic, key, name, recovered, debug.Stack())
+		}
+	}()
+
+	return sc.resolveUnguarded(ctx, key, name)
+}
+
+// resolveUnguarded runs the resolution pipeline without recovering panics.
+func (sc *ServiceContainer) resolveUnguarded(ctx context.Context, key reflect.Type, name string) (any, error) {
+	if err := checkContext(ctx, key, name); err != nil {
+		return nil, err
+	}
+
+	overrides, overridden := ctx.Value(overridesContextKey{}).(map[reflect.Type]any)
+	if override, exists := overrides[key]; exists {
+		return override, nil
+	}
+
+	// Types replaced via WithOverrides are resolved from the overriding container, and
+	// instances constructed on its behalf are not cached to leave this container untouched
+	seam, seamed := overrideSeam(ctx, sc)
+	if seamed && seam.overrides[key] {
+		return seam.resolveInstance(ctx, key, "")
+	}
+	overridden = overridden || seamed
+
+	sc.mu.RLock()
+	resolvedName := sc.resolveAlias(key, name)
+	serviceMaps, exists := sc.services[key]
+	service, registered := serviceMaps[resolvedName]
+	sc.mu.RUnlock()
+
+	if !registered {
 		sc.mu.RLock()
-		singletonsMaps, exists := sc.singletons[key]
+		found, foundName, foundType, err := sc.findRegistration(key, name)
 		sc.mu.RUnlock()
 
-		if exists {
-			if singleton, exists := singletonsMaps[name]; exists {
-				if typed, ok := singleton.(T); ok {
-					return typed, nil
-				}
-				return zero, fmt.Errorf("failed to cast stored singleton to %T", zero)
+		if err != nil {
+			return nil, err
+		}
+
+		// Registrations found via interface fallback are resolved for the interface they are registered for
+		if found != nil && foundType != key {
+			return sc.resolveInstance(ctx, foundType, name)
+		}
+
+		if sc.parent == nil {
+			if !exists {
+				return nil, fmt.Errorf("registration for '%s': %w", key, ErrNotRegistered)
 			}
+			return nil, fmt.Errorf("registration for '%s' and name '%s': %w", key, name, ErrNotRegistered)
+		}
+
+		// Fall back to the parent container for registrations not shadowed by this container,
+		// except for scoped registrations of ancestors, which are instantiated and cached within the scope
+		if found == nil || !found.IsScoped || !sc.scoped {
+			return sc.parent.resolveInstance(ctx, key, name)
 		}
 
-		// Double mutex lock checking
-		if singletonsMaps, exists := sc.singletons[key]; exists {
-			if singleton, exists := singletonsMaps[name]; exists {
-				if typed, ok := singleton.(T); ok {
-					return typed, nil
-				}
-				return zero, fmt.Errorf("failed to cast stored singleton to %T", zero)
+		service, resolvedName = found, foundName
+	}
+
+	name = resolvedName
+
+	if capability, missing := sc.missingCapability(service); missing {
+		return nil, fmt.Errorf("registration for '%s' and name '%s' requires capability '%s': %w", key, name, capability, ErrNotRegistered)
+	}
+
+	if service.IsScoped && !sc.scoped {
+		return nil, fmt.Errorf("scoped registration for '%s' and name '%s' can only be resolved within a scope", key, name)
+	}
+
+	// Singletons are cached in the container owning the registration, scoped services in the scope
+	cached := (service.IsSingleton || service.IsScoped) && !freshInstances(ctx)
+
+	node, ctx := recordGraphNode(ctx, key, name, service)
+
+	// Limited registrations wait before checking the cache, so waiting resolutions of a
+	// singleton return the instance constructed in the meantime
+	release, err := service.acquireConstruction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for construction of '%s' with name '%s': %w", key, name, err)
+	}
+	defer release()
+
+	slot := cacheSlot{service: service}
+	if cached && service.CacheKey != nil {
+		slot.key = service.CacheKey(ctx)
+	}
+
+	if cached {
+		sc.mu.RLock()
+		singleton, exists := sc.singletons[slot]
+		view, viewed := sc.views[viewSlot{slot: slot, key: key, name: name}]
+		failure, failed := sc.failures[slot]
+		sc.mu.RUnlock()
+
+		if viewed {
+			node.markCached()
+			if sc.cacheHitMiddlewares.Load() {
+				return sc.processMiddlewares(ctx, key, name, service, view, true)
 			}
+			return view, nil
+		}
+
+		// Singletons resolved via another type or name are shared, but processed for this type
+		if exists {
+			node.markCached()
+			return sc.cacheView(ctx, key, name, slot, singleton, overridden)
+		}
+
+		if failed {
+			return nil, failure
 		}
 	}
 
-	singleton, err := service.Factory(ctx, sc)
+	if service.Factory == nil {
+		return nil, fmt.Errorf("no factory available for '%s' and name '%s'", key, name)
+	}
+
+	timingType := key
+	if service.Type != nil {
+		timingType = service.Type
+	}
+
+	constructed := time.Now()
+	started, timing := sc.timer.start()
+	factoryCtx, cancel := service.constructContext(context.WithValue(ContextWithName(ctx, name), requestedTypeContextKey{}, key))
+	instance, err := service.Factory(factoryCtx, sc)
+	err = service.checkConstructTimeout(factoryCtx, "factory", err)
+	cancel()
+	if timing {
+		sc.timer.record(timingType, "factory", started)
+	}
 	if err != nil {
-		return zero, err
+		return nil, err
 	}
 
-	_, ok := singleton.(T)
-	if !ok {
-		return zero, fmt.Errorf("failed to cast singleton to '%T': %s", zero, err)
+	if err := checkContext(ctx, key, name); err != nil {
+		return nil, sc.abortConstruction(ctx, instance, err)
 	}
 
-	for _, middleware := range sc.middlewares {
-		singleton, err = middleware.Process(ctx, key, singleton)
-		if err != nil {
-			return zero, fmt.Errorf("failed to process middleware during singleton creator of '%T': %s", zero, err)
+	if isNil(instance) {
+		return nil, fmt.Errorf("factory for '%s' and name '%s' returned no instance: %w", key, name, ErrNilInstance)
+	}
+
+	// Lifecycle initialization and cleanup apply to the unprocessed instance shared by all
+	// types, while middlewares and decorators apply to the requested type
+	undecorated := instance
+	instance, err = sc.processMiddlewares(context.WithValue(ctx, constructionContextKey{}, constructed), key, name, service, instance, false)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err = sc.decorate(ctx, key, name, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkContext(ctx, key, name); err != nil {
+		return nil, sc.abortConstruction(ctx, undecorated, err)
+	}
+
+	if cached {
+		// Double checking, since a concurrent resolution may have cached the singleton in the meantime
+		sc.mu.RLock()
+		singleton, exists := sc.singletons[slot]
+		failure, failed := sc.failures[slot]
+		sc.mu.RUnlock()
+
+		if exists {
+			node.markCached()
+			return sc.adoptSingleton(ctx, key, name, slot, undecorated, singleton, overridden)
+		}
+
+		if failed {
+			return nil, sc.abortConstruction(ctx, undecorated, failure)
 		}
 	}
 
+	// Init runs without holding the lock, so a slow initialization does not block unrelated
+	// resolutions and registrations
+	started, timing = sc.timer.start()
+	lifecycle, err := sc.initLifecycle(ctx, undecorated, service)
+	if timing {
+		sc.timer.record(timingType, "init", started)
+	}
+	if err != nil {
+		if cached && service.CacheFailedInit {
+			sc.mu.Lock()
+			sc.failures[slot] = err
+			sc.mu.Unlock()
+		}
+		return nil, err
+	}
+
 	sc.mu.Lock()
-	defer sc.mu.Unlock()
 
-	if err := sc.runLifecycle(ctx, singleton); err != nil {
-		return zero, err
+	// Instances constructed with overrides are never cached to leave the container untouched
+	if cached && !overridden {
+		if singleton, exists := sc.singletons[slot]; exists {
+			sc.releaseInit(lifecycle)
+			sc.mu.Unlock()
+
+			node.markCached()
+			return sc.adoptSingleton(ctx, key, name, slot, undecorated, singleton, overridden)
+		}
+
+		sc.singletons[slot] = undecorated
+		sc.storeView(viewSlot{slot: slot, key: key, name: name}, instance)
 	}
 
-	if service.IsSingleton {
-		singletonsMaps, exists := sc.singletons[key]
-		if exists {
-			singletonsMaps[name] = singleton
-		} else {
-			singletonsMaps = make(map[string]any)
-			singletonsMaps[name] = singleton
+	sc.trackLifecycle(ctx, lifecycle, service, cached && !overridden)
+	sc.mu.Unlock()
+
+	if len(service.AfterResolve) > 0 {
+		if session, ok := sessionFromContext(ctx); ok {
+			session.deferCallbacks(sc, key, name, slot, undecorated, cached && !overridden)
+		}
+	}
+
+	return instance, nil
+}
+
+// processMiddlewares runs the global middlewares, followed by the registration-specific
+// middlewares, on the constructed or cached instance.
+func (sc *ServiceContainer) processMiddlewares(ctx context.Context, key reflect.Type, name string, service *RegistrationService, instance any, cacheHit bool) (any, error) {
+	sc.mu.RLock()
+	middlewares := append(append([]MiddlewareServiceV2{}, sc.middlewares...), service.Middlewares...)
+	sc.mu.RUnlock()
+
+	info := MiddlewareInfo{
+		Type:      key,
+		Name:      name,
+		Singleton: service.IsSingleton,
+		CacheHit:  cacheHit,
+	}
+
+	stage := "creation"
+	if cacheHit {
+		stage = "resolution"
+	}
+
+	for _, middleware := range middlewares {
+		var err error
+		instance, err = middleware.Process(ctx, info, instance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process middleware during %s of '%s': %w", stage, key, err)
+		}
 
-			sc.singletons[key] = singletonsMaps
+		if isNil(instance) {
+			return nil, fmt.Errorf("middleware for '%s' returned no instance: %w", key, ErrNilInstance)
 		}
 	}
 
-	return singleton.(T), nil
+	return instance, nil
+}
+
+// checkContext returns a resolution error wrapping the context error if the provided
+// context has been cancelled or its deadline has been exceeded.
+func checkContext(ctx context.Context, key reflect.Type, name string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("resolution of '%s' and name '%s' aborted: %w", key, name, err)
+	}
+
+	return nil
 }
 
 // Resolve resolves a service of type T from the container using an empty name.
@@ -124,6 +572,216 @@ func Resolve[T any](ctx context.Context, sc *ServiceContainer) (T, error) {
 	return ResolveName[T](ctx, sc, "")
 }
 
+// Get resolves a service of type T, optionally with a name. Without a name, it is
+// equivalent to Resolve; with a single name, it is equivalent to ResolveName. Passing
+// more than one name returns an error.
+//
+// Example:
+//
+//	logger, err := Get[Logger](ctx, container)
+//	pgDB, err := Get[Database](ctx, container, "postgres")
+func Get[T any](ctx context.Context, sc *ServiceContainer, name ...string) (T, error) {
+	switch len(name) {
+	case 0:
+		return Resolve[T](ctx, sc)
+	case 1:
+		return ResolveName[T](ctx, sc, name[0])
+	default:
+		var zero T
+		return zero, fmt.Errorf("failed to resolve '%s': expected at most one name, got %d", typeKey[T](), len(name))
+	}
+}
+
+// NamedKey is implemented by typed keys, such as enums, that identify named registrations.
+// Key returns the registration name the key stands for.
+type NamedKey interface {
+	Key() string
+}
+
+// ResolveKey resolves a service of type T with the name returned by the provided key.
+// This allows callers to pass typed keys instead of string literals, while registrations
+// keep using plain names, e.g. via AsNamed(ShardA.Key()).
+//
+// Example:
+//
+//	type Shard int
+//
+//	const (
+//		ShardA Shard = iota
+//		ShardB
+//	)
+//
+//	func (s Shard) Key() string {
+//		return [...]string{"shard-a", "shard-b"}[s]
+//	}
+//
+//	conn, err := ResolveKey[*ShardConnection](ctx, container, ShardA)
+func ResolveKey[T any](ctx context.Context, sc *ServiceContainer, key NamedKey) (T, error) {
+	if key == nil {
+		var zero T
+		return zero, fmt.Errorf("failed to resolve '%s': key must not be nil", typeKey[T]())
+	}
+
+	return ResolveName[T](ctx, sc, key.Key())
+}
+
+// ResolveFirst resolves a service of type T by trying the provided names in order and
+// returning the first instance that resolves successfully, modelling a graceful fallback
+// across several configured backends. Use the empty name to fall back to the default
+// registration.
+//
+// Both missing registrations and failed constructions move on to the next name, so a
+// broken backend does not prevent the fallback. If all names fail, the returned error
+// aggregates the error of every attempt and can be inspected via errors.Is, e.g. for
+// ErrNotRegistered. Resolution stops early once the context is done.
+//
+// Example:
+//
+//	cache, err := ResolveFirst[Cache](ctx, container, "cache-redis", "cache-memory", "")
+func ResolveFirst[T any](ctx context.Context, sc *ServiceContainer, names ...string) (T, error) {
+	var zero T
+	key := typeKey[T]()
+
+	if len(names) == 0 {
+		return zero, fmt.Errorf("failed to resolve '%s': no names provided", key)
+	}
+
+	errs := &Errors{}
+	for _, name := range names {
+		instance, err := ResolveName[T](ctx, sc, name)
+		if err == nil {
+			return instance, nil
+		}
+
+		errs.Add(fmt.Errorf("failed to resolve '%s' with name '%s': %w", key, name, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return zero, fmt.Errorf("failed to resolve '%s' from any of the names %q: %w", key, names, errs.Errors())
+}
+
+// ResolveWithRelease resolves a service of type T using an empty name and returns a
+// release function for the caller to call once it is done with the instance. For
+// instances owned by the caller, such as those of registrations created with
+// AsTransient, the release function calls Cleanup if the instance implements
+// LifecycleService. For instances managed by the container, such as singletons,
+// the release function does nothing, since they are cleaned up by the container.
+//
+// Example:
+//
+//	buffer, release, err := ResolveWithRelease[*RequestBuffer](ctx, container)
+//	if err != nil {
+//		return err
+//	}
+//	defer release(ctx)
+func ResolveWithRelease[T any](ctx context.Context, sc *ServiceContainer) (T, func(context.Context) error, error) {
+	resolved, err := Resolve[T](ctx, sc)
+	if err != nil {
+		return resolved, nil, err
+	}
+
+	release := func(context.Context) error {
+		return nil
+	}
+
+	if lifecycle, ok := any(resolved).(LifecycleService); ok && !sc.isManaged(resolved) {
+		release = lifecycle.Cleanup
+	}
+
+	return resolved, release, nil
+}
+
+// ResolveFactory returns a function resolving the unnamed registration of type T on every
+// call, allowing consumers to construct instances lazily and repeatedly with their own
+// context. Each call runs the full resolution pipeline, so singletons are only constructed
+// once and returned from the cache afterwards, while transient registrations construct a
+// new instance per call. An error is returned immediately if T is not registered.
+//
+// Example:
+//
+//	newConn, err := ResolveFactory[*Connection](ctx, container)
+//	if err != nil {
+//		return err
+//	}
+//
+//	for _, job := range jobs {
+//		conn, err := newConn(job.Context())
+//		...
+//	}
+func ResolveFactory[T any](ctx context.Context, sc *ServiceContainer) (func(context.Context) (T, error), error) {
+	key := typeKey[T]()
+	if err := checkContext(ctx, key, ""); err != nil {
+		return nil, err
+	}
+
+	if !sc.isRegistered(key, "") {
+		return nil, fmt.Errorf("failed to resolve factory for '%s': %w", key, ErrNotRegistered)
+	}
+
+	return func(ctx context.Context) (T, error) {
+		return ResolveName[T](ctx, sc, "")
+	}, nil
+}
+
+// overridesContextKey is the context key used to store per-call overrides.
+type overridesContextKey struct{}
+
+// ResolveWith resolves a service of type T while preferring the provided override
+// instances over registrations. Overrides are matched by type and apply to the whole
+// dependency graph of this call only, including fabric tag injection, without mutating
+// the container. Precedence is override > registration.
+//
+// Already cached singletons are returned as usual, but singletons constructed during
+// this call are not cached, as they may contain override instances.
+//
+// Example:
+//
+//	service, err := ResolveWith[*UserService](ctx, container, map[reflect.Type]any{
+//		reflect.TypeOf((*Database)(nil)).Elem(): &StubDatabase{},
+//	})
+func ResolveWith[T any](ctx context.Context, sc *ServiceContainer, overrides map[reflect.Type]any) (T, error) {
+	merged := make(map[reflect.Type]any, len(overrides))
+	if existing, ok := ctx.Value(overridesContextKey{}).(map[reflect.Type]any); ok {
+		for t, override := range existing {
+			merged[t] = override
+		}
+	}
+	for t, override := range overrides {
+		merged[t] = override
+	}
+
+	return Resolve[T](context.WithValue(ctx, overridesContextKey{}, merged), sc)
+}
+
+// ResolveConcrete resolves a service by its concrete type T, ignoring any registrations
+// that were only mapped to T via With[T]() or WithName[T](name) by another type.
+// Since Register always stores the concrete type under its own key, this is usually
+// equivalent to Resolve, but makes the intent explicit for generic framework code that
+// must never receive an implementation registered under an interface mapping.
+//
+// Example:
+//
+//	Register[*PostgresDB](container, WithName[Database]("pg"))
+//
+//	// Always returns the registration of *PostgresDB itself
+//	db, err := ResolveConcrete[*PostgresDB](ctx, container)
+func ResolveConcrete[T any](ctx context.Context, sc *ServiceContainer) (T, error) {
+	var zero T
+	key := typeKey[T]()
+
+	sc.mu.RLock()
+	service, exists := sc.services[key][""]
+	sc.mu.RUnlock()
+
+	if !exists || service.Type != key {
+		return zero, fmt.Errorf("concrete registration for '%s' not found", key)
+	}
+
+	return ResolveName[T](ctx, sc, "")
+}
+
 // ResolveNameAs resolves a named service of type T and assigns it to the provided pointer.
 // This method is useful when you want to avoid declaring a new variable and prefer
 // to assign directly to an existing variable reference.
@@ -162,3 +820,42 @@ func ResolveAs[T any](ctx context.Context, sc *ServiceContainer, t *T) error {
 	*t = resolved
 	return nil
 }
+
+// Inject populates the fabric-tagged fields of an existing struct instance. The target
+// must be a non-nil pointer to a struct; each settable field carrying a fabric tag is
+// resolved through the container's tag processors, exactly as for services created by
+// the container itself. Fields without a fabric tag are left untouched.
+//
+// This is useful for wiring structs that are not created by the container, such as
+// HTTP handlers constructed by a router.
+//
+// Example:
+//
+//	handler := &UserHandler{Router: router}
+//	if err := sc.Inject(ctx, handler); err != nil {
+//		return err
+//	}
+func (sc *ServiceContainer) Inject(ctx context.Context, target any) error {
+	val := reflect.ValueOf(target)
+	if !val.IsValid() || val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("inject target must be a non-nil pointer to a struct, got %T", target)
+	}
+
+	structVal := val.Elem()
+	if structVal.Kind() != reflect.Struct {
+		return fmt.Errorf("inject target must be a non-nil pointer to a struct, got %T", target)
+	}
+
+	// Validate all tags upfront to avoid partially injected targets
+	if err := sc.validateFieldPlans(fabricFields(structVal.Type())); err != nil {
+		return err
+	}
+
+	return sc.withSession(ctx, func(ctx context.Context) error {
+		if err := injectFabricTags(ctx, sc, structVal); err != nil {
+			return fmt.Errorf("failed to inject into '%T': %w", target, err)
+		}
+
+		return nil
+	})
+}
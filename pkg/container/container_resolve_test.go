@@ -0,0 +1,1108 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolveNilInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc,
+		With[LoggerEngine](),
+		AsSingleton(),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			return nil, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance, got %v", err)
+	}
+
+	if _, err := Resolve[*LoggerService](ctx, sc); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance, got %v", err)
+	}
+}
+
+func TestResolveTypedNilInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc,
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			var logger *LoggerService
+			return logger, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*LoggerService](ctx, sc); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance, got %v", err)
+	}
+}
+
+func TestTagInjectionNilInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*Agent](sc))
+	errs.Add(Register[*LoggerService](sc,
+		With[LoggerEngine](),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			return nil, nil
+		})))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*Agent](ctx, sc); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance, got %v", err)
+	}
+}
+
+func TestInjectExistingInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	agent := &Agent{}
+	if err := sc.Inject(ctx, agent); err != nil {
+		t.Fatalf("Failed to inject agent: %v", err)
+	}
+
+	if agent.Logger == nil {
+		t.Error("Logger was not successfully injected")
+	}
+	if agent.Encrypt == nil {
+		t.Error("Encrypt was not successfully injected")
+	}
+
+	if err := sc.Inject(ctx, Agent{}); err == nil {
+		t.Error("Expected injection into non-pointer target to fail")
+	}
+}
+
+func TestResolveCancelledContext(t *testing.T) {
+	sc := NewServiceContainer()
+
+	called := false
+	if err := Register[*LoggerService](sc,
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			called = true
+			return &LoggerService{}, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err := Resolve[*LoggerService](ctx, sc); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if called {
+		t.Error("Expected factory not to be called for cancelled context")
+	}
+}
+
+func TestResolveCancelledAfterConstructionCleansUpInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var cancelled, decorated *CounterService
+	errs := &Errors{}
+	errs.Add(Register[*CounterService](sc, AsNamed("factory"),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			cancelled = &CounterService{}
+			cancel()
+			return cancelled, nil
+		})))
+	errs.Add(Register[*CounterService](sc, AsNamed("decorator"),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			decorated = &CounterService{}
+			return decorated, nil
+		})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := ResolveName[*CounterService](ctx, sc, "factory"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	if cancelled.cleanups != 1 {
+		t.Errorf("Expected instance constructed before the cancellation to be cleaned up, got %d cleanups", cancelled.cleanups)
+	}
+
+	decorating, cancelDecorating := context.WithCancel(t.Context())
+	defer cancelDecorating()
+
+	DecorateName(sc, "decorator", func(ctx context.Context, sc *ServiceContainer, counter *CounterService) (*CounterService, error) {
+		cancelDecorating()
+		return counter, nil
+	})
+
+	if _, err := ResolveName[*CounterService](decorating, sc, "decorator"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	if decorated.cleanups != 1 {
+		t.Errorf("Expected decorated instance to be cleaned up, got %d cleanups", decorated.cleanups)
+	}
+}
+
+func TestResolveConcreteWithNamedInterface(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, WithName[LoggerEngine]("console")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*LoggerService](ctx, sc); err != nil {
+		t.Errorf("Expected concrete type to be resolvable: %v", err)
+	}
+
+	if _, err := ResolveConcrete[*LoggerService](ctx, sc); err != nil {
+		t.Errorf("Expected concrete type to be resolvable: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err == nil {
+		t.Error("Expected unnamed interface resolution to fail")
+	}
+
+	if _, err := ResolveConcrete[LoggerEngine](ctx, sc); err == nil {
+		t.Error("Expected concrete resolution of interface to fail")
+	}
+}
+
+type StubLogger struct{}
+
+func (sl *StubLogger) Debug(msg string, args ...any) {}
+
+func TestResolveWithOverrides(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*AuditService](sc, AsSingleton()))
+	errs.Add(Register[*Agent](sc, AsSingleton()))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	stub := &StubLogger{}
+	audit, err := ResolveWith[*AuditService](ctx, sc, map[reflect.Type]any{
+		typeKey[LoggerEngine](): stub,
+	})
+	if err != nil {
+		t.Fatalf("Failed to resolve with overrides: %v", err)
+	}
+
+	if audit.Agent.Logger != stub {
+		t.Error("Expected override to flow down the dependency graph")
+	}
+
+	agent, err := Resolve[*Agent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger == stub {
+		t.Error("Expected override not to leak into the container")
+	}
+}
+
+func TestResolveWithOverridesCachedSingleton(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine](), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	stub := &StubLogger{}
+	logger, err := ResolveWith[LoggerEngine](ctx, sc, map[reflect.Type]any{
+		typeKey[LoggerEngine](): stub,
+	})
+	if err != nil {
+		t.Fatalf("Failed to resolve with overrides: %v", err)
+	}
+
+	if logger != stub {
+		t.Error("Expected override to take precedence over the cached singleton")
+	}
+}
+
+func TestFactoryRegistersCompanionService(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	err := Register[*EncryptService](sc, AsSingleton(), AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		if err := Register[*NamedLogger](sc, WithInstance(&NamedLogger{name: "encrypt"})); err != nil {
+			return nil, err
+		}
+		return &EncryptService{}, nil
+	}))
+	if err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*NamedLogger](ctx, sc); !errors.Is(err, ErrNotRegistered) {
+		t.Fatalf("Expected companion not to be registered before construction, got %v", err)
+	}
+
+	if _, err := Resolve[*EncryptService](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve encrypt: %v", err)
+	}
+
+	logger, err := Resolve[*NamedLogger](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve companion registered by factory: %v", err)
+	}
+
+	if logger.name != "encrypt" {
+		t.Errorf("Expected companion instance, got '%s'", logger.name)
+	}
+}
+
+func TestFactoryReceivesRequestedName(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	factory := AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return &NamedLogger{name: NameFromContext(ctx)}, nil
+	})
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, AsNamed("shard-1"), factory))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("shard-2"), factory))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for _, name := range []string{"shard-1", "shard-2"} {
+		logger, err := ResolveName[*NamedLogger](ctx, sc, name)
+		if err != nil {
+			t.Fatalf("Failed to resolve logger: %v", err)
+		}
+		if logger.name != name {
+			t.Errorf("Expected factory to receive name '%s', got '%s'", name, logger.name)
+		}
+	}
+}
+
+func TestResolveTypeMismatch(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return &EncryptService{}, nil
+	})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	_, err := Resolve[*LoggerService](ctx, sc)
+	if err == nil {
+		t.Fatal("Expected resolution of mismatching factory result to fail")
+	}
+
+	for _, name := range []string{"*container.EncryptService", "*container.LoggerService"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected error to contain '%s', got: %v", name, err)
+		}
+	}
+}
+
+func TestRecoverPanics(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*CounterAgent](sc))
+	errs.Add(Register[*CounterService](sc, AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		panic("factory exploded")
+	})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	sc.SetRecoverPanics(true)
+
+	_, err := Resolve[*CounterAgent](ctx, sc)
+	if !errors.Is(err, ErrPanic) {
+		t.Fatalf("Expected recovered panic error, got %v", err)
+	}
+
+	for _, expected := range []string{"*container.CounterService", "factory exploded"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain '%s', got: %v", expected, err)
+		}
+	}
+
+	// The container remains usable after a recovered panic
+	if _, err := Resolve[*CacheService](ctx, sc); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected container to remain usable, got %v", err)
+	}
+
+	sc.SetRecoverPanics(false)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic to propagate with recovery disabled")
+		}
+	}()
+	_, _ = Resolve[*CounterService](ctx, sc)
+}
+
+func TestGetWithOptionalName(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*StubLogger](sc, WithName[LoggerEngine]("stub")))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if logger, err := Get[LoggerEngine](ctx, sc); err != nil {
+		t.Errorf("Failed to get default logger: %v", err)
+	} else if _, ok := logger.(*LoggerService); !ok {
+		t.Errorf("Expected default logger, got %T", logger)
+	}
+
+	if logger, err := Get[LoggerEngine](ctx, sc, "stub"); err != nil {
+		t.Errorf("Failed to get named logger: %v", err)
+	} else if _, ok := logger.(*StubLogger); !ok {
+		t.Errorf("Expected named logger, got %T", logger)
+	}
+
+	if _, err := Get[LoggerEngine](ctx, sc, "stub", "other"); err == nil {
+		t.Error("Expected multiple names to fail")
+	}
+}
+
+func BenchmarkResolveSingleton(b *testing.B) {
+	sc := NewServiceContainer()
+	ctx := b.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine](), AsSingleton()); err != nil {
+		b.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+			b.Fatalf("Failed to resolve logger: %v", err)
+		}
+	}
+}
+
+func BenchmarkResolveSingletonParallel(b *testing.B) {
+	sc := NewServiceContainer()
+	ctx := b.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine](), AsSingleton()); err != nil {
+		b.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+				b.Errorf("Failed to resolve logger: %v", err)
+				return
+			}
+		}
+	})
+}
+
+func TestTypeKey(t *testing.T) {
+	if key := typeKey[LoggerEngine](); key.Kind() != reflect.Interface || key != reflect.TypeOf((*LoggerEngine)(nil)).Elem() {
+		t.Errorf("Expected interface type key, got '%s'", key)
+	}
+
+	if key := typeKey[*LoggerService](); key != reflect.TypeOf(&LoggerService{}) {
+		t.Errorf("Expected pointer type key, got '%s'", key)
+	}
+
+	if key := typeKey[any](); key.Kind() != reflect.Interface || key.NumMethod() != 0 {
+		t.Errorf("Expected empty interface type key, got '%s'", key)
+	}
+}
+
+func BenchmarkTypeKey(b *testing.B) {
+	for b.Loop() {
+		_ = typeKey[LoggerEngine]()
+		_ = typeKey[*LoggerService]()
+	}
+}
+
+func TestResolveWithFreshInstances(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*AuditService](sc, AsSingleton()))
+	errs.Add(Register[*Agent](sc, AsSingleton()))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	cached, err := Resolve[*AuditService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve audit: %v", err)
+	}
+
+	fresh, err := Resolve[*AuditService](WithFreshInstances(ctx), sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve fresh audit: %v", err)
+	}
+
+	if fresh == cached || fresh.Agent == cached.Agent {
+		t.Error("Expected fresh instances for the service and its dependencies")
+	}
+
+	again, err := Resolve[*AuditService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve audit: %v", err)
+	}
+
+	if again != cached {
+		t.Error("Expected fresh resolution to leave the singleton cache untouched")
+	}
+}
+
+func TestSingletonSharedAcrossInterfaceAndConcrete(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*NamedLogger](sc, With[LoggerEngine](), WithName[LoggerEngine]("named"), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	logger, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	concrete, err := Resolve[*NamedLogger](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve concrete logger: %v", err)
+	}
+
+	named, err := ResolveName[LoggerEngine](ctx, sc, "named")
+	if err != nil {
+		t.Fatalf("Failed to resolve named logger: %v", err)
+	}
+
+	if logger != LoggerEngine(concrete) || named != logger {
+		t.Error("Expected interface and concrete resolutions to return the same singleton")
+	}
+}
+
+type Shard int
+
+const (
+	ShardA Shard = iota
+	ShardB
+)
+
+func (s Shard) Key() string {
+	return [...]string{"shard-a", "shard-b"}[s]
+}
+
+func TestResolveKey(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, AsNamed(ShardA.Key()), WithInstance(&NamedLogger{name: "a"})))
+	errs.Add(Register[*NamedLogger](sc, AsNamed(ShardB.Key()), WithInstance(&NamedLogger{name: "b"})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for shard, expected := range map[Shard]string{ShardA: "a", ShardB: "b"} {
+		logger, err := ResolveKey[*NamedLogger](ctx, sc, shard)
+		if err != nil {
+			t.Fatalf("Failed to resolve logger for '%s': %v", shard.Key(), err)
+		}
+
+		if logger.name != expected {
+			t.Errorf("Expected logger '%s' for '%s', got '%s'", expected, shard.Key(), logger.name)
+		}
+	}
+
+	if _, err := ResolveKey[*NamedLogger](ctx, sc, nil); err == nil {
+		t.Error("Expected resolution with nil key to fail")
+	}
+}
+
+func TestResolveFirst(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	failure := errors.New("connection refused")
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, WithName[LoggerEngine]("cache-redis"), AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return nil, failure
+	})))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	logger, err := ResolveFirst[LoggerEngine](ctx, sc, "cache-redis", "cache-memory", "")
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if _, ok := logger.(*LoggerService); !ok {
+		t.Errorf("Expected fallback to default logger, got %T", logger)
+	}
+
+	_, err = ResolveFirst[LoggerEngine](ctx, sc, "cache-redis", "cache-memory")
+	if !errors.Is(err, failure) || !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected aggregated construction and not-found errors, got %v", err)
+	}
+
+	if _, err := ResolveFirst[LoggerEngine](ctx, sc); err == nil {
+		t.Error("Expected resolution without names to fail")
+	}
+}
+
+type CryptoLogger struct {
+	requested reflect.Type
+}
+
+func (cl *CryptoLogger) Debug(msg string, args ...any) {}
+
+func (cl *CryptoLogger) Encrypt(ctx context.Context, plain []byte) ([]byte, error) {
+	return plain, nil
+}
+
+func TestRequestedTypeFromContext(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*CryptoLogger](sc, With[LoggerEngine](), With[EncryptEngine](),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			return &CryptoLogger{requested: RequestedTypeFromContext(ctx)}, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	logger, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	encrypt, err := Resolve[EncryptEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve encrypt: %v", err)
+	}
+
+	concrete, err := Resolve[*CryptoLogger](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve crypto logger: %v", err)
+	}
+
+	if requested := logger.(*CryptoLogger).requested; requested != typeKey[LoggerEngine]() {
+		t.Errorf("Expected factory to be invoked for LoggerEngine, got %v", requested)
+	}
+
+	if requested := encrypt.(*CryptoLogger).requested; requested != typeKey[EncryptEngine]() {
+		t.Errorf("Expected factory to be invoked for EncryptEngine, got %v", requested)
+	}
+
+	if concrete.requested != typeKey[*CryptoLogger]() {
+		t.Errorf("Expected factory to be invoked for the concrete type, got %v", concrete.requested)
+	}
+
+	if RequestedTypeFromContext(ctx) != nil {
+		t.Error("Expected no requested type outside of factories")
+	}
+}
+
+func TestMaxConcurrentConstruction(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	var active, peak, constructed atomic.Int32
+	if err := Register[*NamedLogger](sc, WithMaxConcurrentConstruction(1),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			current := active.Add(1)
+			defer active.Add(-1)
+
+			for {
+				observed := peak.Load()
+				if current <= observed || peak.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+
+			constructed.Add(1)
+			time.Sleep(time.Millisecond)
+			return &NamedLogger{}, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := &Errors{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := Resolve[*NamedLogger](ctx, sc)
+			errs.Add(err)
+		}()
+	}
+	wg.Wait()
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if peak.Load() != 1 || constructed.Load() != 20 {
+		t.Errorf("Expected 20 sequential constructions, got %d with a peak of %d", constructed.Load(), peak.Load())
+	}
+}
+
+func TestMaxConcurrentConstructionSingleton(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	var constructed atomic.Int32
+	if err := Register[*NamedLogger](sc, AsSingleton(), WithMaxConcurrentConstruction(1),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			constructed.Add(1)
+			time.Sleep(time.Millisecond)
+			return &NamedLogger{}, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Resolve[*NamedLogger](ctx, sc); err != nil {
+				t.Errorf("Failed to resolve logger: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if constructed.Load() != 1 {
+		t.Errorf("Expected waiting resolutions to share the singleton, got %d constructions", constructed.Load())
+	}
+}
+
+func TestMaxConcurrentConstructionCancellation(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	if err := Register[*NamedLogger](sc, WithMaxConcurrentConstruction(1),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			close(started)
+			<-unblock
+			return &NamedLogger{}, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := Resolve[*NamedLogger](ctx, sc)
+		done <- err
+	}()
+	<-started
+
+	waiting, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := Resolve[*NamedLogger](waiting, sc); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected waiting resolution to fail with its context, got %v", err)
+	}
+
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Errorf("Failed to resolve logger: %v", err)
+	}
+
+	if err := Register[*NamedLogger](sc, WithMaxConcurrentConstruction(0)); err == nil {
+		t.Error("Expected limit below 1 to be rejected")
+	}
+}
+
+func TestMaxConcurrentConstructionDoesNotBlockUnrelatedRegistrations(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	unblock := make(chan struct{})
+	constructed := make(chan *BlockingInitService, 2)
+	errs := &Errors{}
+	errs.Add(Register[*BlockingInitService](sc, WithMaxConcurrentConstruction(1),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			instance := &BlockingInitService{started: make(chan struct{}), unblock: unblock}
+			constructed <- instance
+			return instance, nil
+		})))
+	errs.Add(Register[*CounterService](sc, WithMaxConcurrentConstruction(1)))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := Resolve[*BlockingInitService](ctx, sc)
+			done <- err
+		}()
+	}
+	<-(<-constructed).started
+
+	unrelated := make(chan error)
+	go func() {
+		_, err := Resolve[*CounterService](ctx, sc)
+		unrelated <- err
+	}()
+
+	select {
+	case err := <-unrelated:
+		if err != nil {
+			t.Errorf("Failed to resolve unrelated registration: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected unrelated registration not to wait for the running construction")
+	}
+
+	select {
+	case <-constructed:
+		t.Error("Expected second construction to wait for the running Init")
+	default:
+	}
+
+	close(unblock)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("Failed to resolve service with slow Init: %v", err)
+		}
+	}
+}
+
+func TestConstructTimeout(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, AsNamed("honoring"), WithConstructTimeout(10*time.Millisecond),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Second):
+				return &NamedLogger{}, nil
+			}
+		})))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("ignoring"), WithConstructTimeout(5*time.Millisecond),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			time.Sleep(20 * time.Millisecond)
+			return &NamedLogger{}, nil
+		})))
+	errs.Add(Register[*NamedLogger](sc, AsNamed("fast"), WithConstructTimeout(time.Second),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				return nil, errors.New("expected factory context to carry a deadline")
+			}
+			return &NamedLogger{}, nil
+		})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	started := time.Now()
+	_, err := ResolveName[*NamedLogger](ctx, sc, "honoring")
+	if !errors.Is(err, ErrConstructTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected ErrConstructTimeout wrapping the factory error, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected factory to be aborted by the timeout, took %s", elapsed)
+	}
+
+	if _, err := ResolveName[*NamedLogger](ctx, sc, "ignoring"); !errors.Is(err, ErrConstructTimeout) {
+		t.Errorf("Expected late factory result to be discarded, got %v", err)
+	}
+
+	if _, err := ResolveName[*NamedLogger](ctx, sc, "fast"); err != nil {
+		t.Errorf("Failed to resolve logger within timeout: %v", err)
+	}
+
+	if err := Register[*NamedLogger](sc, WithConstructTimeout(0)); err == nil {
+		t.Error("Expected non-positive timeout to be rejected")
+	}
+}
+
+func TestInstanceAndFactorySharedAcrossInterfaceAndConcrete(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	instance := &NamedLogger{name: "instance"}
+	calls := 0
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, WithInstance(instance), With[LoggerEngine]()))
+	errs.Add(Register[*CryptoLogger](sc, AsSingleton(), With[EncryptEngine](),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			calls++
+			return &CryptoLogger{}, nil
+		})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	concrete, err := Resolve[*NamedLogger](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve concrete logger: %v", err)
+	}
+
+	logger, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if concrete != instance || logger != LoggerEngine(instance) {
+		t.Error("Expected concrete and interface resolutions to return the registered instance")
+	}
+
+	// Resolve the interface first to ensure the cache does not depend on the resolution order
+	encrypt, err := Resolve[EncryptEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve encrypt: %v", err)
+	}
+
+	crypto, err := Resolve[*CryptoLogger](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve crypto logger: %v", err)
+	}
+
+	if encrypt != EncryptEngine(crypto) || calls != 1 {
+		t.Errorf("Expected factory singleton to be shared across interface and concrete type, got %d factory calls", calls)
+	}
+}
+
+func TestResolveFactory(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedLogger](sc, namedLoggerFactory("transient")))
+	errs.Add(Register[*CounterService](sc, AsSingleton(), With[CounterEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	newLogger, err := ResolveFactory[*NamedLogger](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger factory: %v", err)
+	}
+
+	first, err := newLogger(ctx)
+	if err != nil {
+		t.Fatalf("Failed to construct logger: %v", err)
+	}
+	second, err := newLogger(ctx)
+	if err != nil {
+		t.Fatalf("Failed to construct logger: %v", err)
+	}
+
+	if first == second || first.name != "transient" {
+		t.Error("Expected transient factory to construct a new instance per call")
+	}
+
+	newCounter, err := ResolveFactory[CounterEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve counter factory: %v", err)
+	}
+
+	if IsInstantiated[*CounterService](sc, "") {
+		t.Error("Expected factory resolution not to construct the singleton")
+	}
+
+	a, errA := newCounter(ctx)
+	b, errB := newCounter(ctx)
+	if errA != nil || errB != nil {
+		t.Fatalf("Failed to construct counter: %v", errors.Join(errA, errB))
+	}
+
+	if a != b || a.Count() != 1 {
+		t.Error("Expected singleton factory to return the cached instance")
+	}
+
+	if _, err := ResolveFactory[EncryptEngine](ctx, sc); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected ErrNotRegistered for unregistered type, got %v", err)
+	}
+}
+
+type tenantContextKey struct{}
+
+func TestWithCacheKey(t *testing.T) {
+	sc := NewServiceContainer()
+
+	calls := 0
+	tenant := func(ctx context.Context) string {
+		name, _ := ctx.Value(tenantContextKey{}).(string)
+		return name
+	}
+
+	err := Register[*NamedLogger](sc, AsSingleton(), With[LoggerEngine](), WithCacheKey(tenant),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			calls++
+			return &NamedLogger{name: tenant(ctx)}, nil
+		}))
+	if err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	ctxA := context.WithValue(t.Context(), tenantContextKey{}, "a")
+	ctxB := context.WithValue(t.Context(), tenantContextKey{}, "b")
+
+	first, err := Resolve[*NamedLogger](ctxA, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger for tenant a: %v", err)
+	}
+	second, err := Resolve[*NamedLogger](ctxB, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger for tenant b: %v", err)
+	}
+
+	if first == second || first.name != "a" || second.name != "b" {
+		t.Fatalf("Expected a separate instance per cache key, got '%s' and '%s'", first.name, second.name)
+	}
+
+	again, err := Resolve[LoggerEngine](ctxA, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger engine for tenant a: %v", err)
+	}
+
+	if again != LoggerEngine(first) || calls != 2 {
+		t.Errorf("Expected instances to be cached per key, got %d factory calls", calls)
+	}
+
+	visited := 0
+	if err := sc.ForEachSingleton(func(t reflect.Type, name string, instance any) error {
+		visited++
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to visit singletons: %v", err)
+	}
+
+	if visited != 2 || !IsInstantiated[*NamedLogger](sc, "") {
+		t.Errorf("Expected both cached instances to be visible, visited %d", visited)
+	}
+
+	if err := Register[*LoggerService](sc, WithCacheKey(nil)); err == nil {
+		t.Error("Expected nil cache key function to be rejected")
+	}
+}
+
+func TestConcurrentSingletonConstructionCleansUpDiscardedInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	var mu sync.Mutex
+	var constructed []*CounterService
+
+	var factories sync.WaitGroup
+	factories.Add(2)
+	if err := Register[*CounterService](sc, AsSingleton(),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			instance := &CounterService{}
+			mu.Lock()
+			constructed = append(constructed, instance)
+			mu.Unlock()
+
+			// Both resolutions construct an instance before either of them is cached
+			factories.Done()
+			factories.Wait()
+			return instance, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	resolved := make([]*CounterService, 2)
+	for i := range resolved {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter, err := Resolve[*CounterService](ctx, sc)
+			if err != nil {
+				t.Errorf("Failed to resolve counter: %v", err)
+			}
+			resolved[i] = counter
+		}()
+	}
+	wg.Wait()
+
+	if resolved[0] != resolved[1] {
+		t.Fatal("Expected both resolutions to share the singleton")
+	}
+
+	for _, instance := range constructed {
+		kept := instance == resolved[0]
+		if kept && instance.cleanups != 0 {
+			t.Errorf("Expected cached singleton not to be cleaned up, got %d cleanups", instance.cleanups)
+		}
+		if !kept && instance.cleanups != 1 {
+			t.Errorf("Expected discarded instance to be cleaned up once, got %d cleanups", instance.cleanups)
+		}
+	}
+}
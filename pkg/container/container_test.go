@@ -0,0 +1,95 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+type FailingCleanupService struct{}
+
+func (fcs *FailingCleanupService) Init(ctx context.Context) error {
+	return nil
+}
+
+func (fcs *FailingCleanupService) Cleanup(ctx context.Context) error {
+	return errors.New("cleanup failed")
+}
+
+func TestResetRestoresConstructedState(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	order := make([]string, 0)
+	errs := &Errors{}
+	errs.Add(Register[*CounterService](sc, AsSingleton(), With[CounterEngine]()))
+	errs.Add(Register[*FailingCleanupService](sc, AsSingleton()))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	sc.AddTagProcessor(&PriorityTagProcessor{})
+	sc.AddMiddleware(&recordingMiddleware{name: "global", order: &order})
+	sc.EnableCapability("metrics")
+	sc.EnableTiming()
+
+	counter, err := Resolve[*CounterService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+	if _, err := Resolve[*FailingCleanupService](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+
+	if err := sc.Reset(ctx); err == nil {
+		t.Error("Expected cleanup errors to be returned")
+	}
+
+	if counter.cleanups != 1 {
+		t.Errorf("Expected singleton to be cleaned up during reset, got %d cleanups", counter.cleanups)
+	}
+
+	fresh := NewServiceContainer()
+	if len(sc.Registrations()) != len(fresh.Registrations()) {
+		t.Errorf("Expected only default registrations to survive, got %v", sc.Registrations())
+	}
+
+	if _, err := Resolve[CounterEngine](ctx, sc); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected registrations to be discarded, got %v", err)
+	}
+
+	if len(sc.TagProcessors()) != len(fresh.TagProcessors()) || sc.tagProcessor.hasProcessorFor("priority") {
+		t.Error("Expected only default tag processors to survive")
+	}
+
+	if len(sc.Capabilities()) != 0 || len(sc.ResolutionStats()) != 0 {
+		t.Error("Expected capabilities and timing to be reset")
+	}
+
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*Agent](sc))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*Agent](ctx, sc); err != nil {
+		t.Fatalf("Expected default inject processor after reset: %v", err)
+	}
+
+	if _, err := Resolve[*Lifecycle](ctx, sc); err != nil {
+		t.Errorf("Expected lifecycle to be registered after reset: %v", err)
+	}
+
+	if !slices.Equal(order, []string{"global", "global"}) {
+		t.Errorf("Expected middlewares to be discarded, got %v", order)
+	}
+
+	if err := sc.Cleanup(ctx); err != nil {
+		t.Errorf("Expected reset services not to be cleaned up again: %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// closerType is the reflect.Type of io.Closer
+var closerType = reflect.TypeOf((*io.Closer)(nil)).Elem()
+
+// dependency describes a single fabric tag dependency of a registration.
+type dependency struct {
+	Type     reflect.Type
+	Name     string
+	Late     bool
+	Optional bool
+}
+
+// String returns a human-readable representation of the dependency.
+func (d dependency) String() string {
+	if d.Name == "" {
+		return d.Type.String()
+	}
+
+	return fmt.Sprintf("%s[%s]", d.Type, d.Name)
+}
+
+// Validate checks whether every registered service can be constructed by walking the
+// dependency graph formed by fabric:"inject" tags. Unlike a check of direct dependencies
+// only, the full graph is traversed transitively for each registration, so a service
+// whose dependency is registered but can not be satisfied itself is reported as well.
+//
+// For each broken registration, the first unsatisfiable chain is reported using a
+// human-readable path. All failures are returned as a single aggregated error:
+//
+//	*app.UserService needs *app.Repository needs app.Database (not registered)
+//
+// Services created by custom factories or pre-created instances are treated as
+// constructible, since their dependencies can not be inspected.
+//
+// Example:
+//
+//	if err := sc.Validate(); err != nil {
+//		log.Fatalf("Invalid container configuration: %v", err)
+//	}
+func (sc *ServiceContainer) Validate() error {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	errs := &Errors{}
+	for _, root := range sc.sortedRegistrations() {
+		// Registrations gated by disabled capabilities are inactive and not validated
+		if _, missing := sc.missingCapability(sc.services[root.Type][root.Name]); missing {
+			continue
+		}
+
+		path := []dependency{root}
+		if broken := sc.validateDependency(path, make(map[dependency]bool)); broken != nil {
+			errs.Add(broken)
+		}
+	}
+
+	return errs.Errors()
+}
+
+// ValidateWithWarnings runs Validate and additionally returns advisory warnings about
+// registrations that are mapped to at least one interface, but whose concrete type also
+// implements further interfaces it is not mapped to. This might indicate a forgotten
+// With[I]() option. Candidate interfaces are all interface types registered in the
+// container, as well as io.Closer, which often indicates missing cleanup wiring.
+//
+// Additionally, singletons injecting transient services are reported as captive
+// dependencies, since the singleton captures a single instance of the transient forever.
+//
+// Warnings never cause an error; the returned error is the result of Validate.
+//
+// Example:
+//
+//	warnings, err := container.ValidateWithWarnings()
+//	for _, warning := range warnings {
+//		log.Printf("[WARN] %s", warning)
+//	}
+func (sc *ServiceContainer) ValidateWithWarnings() ([]string, error) {
+	err := sc.Validate()
+
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	candidates := []reflect.Type{closerType}
+	for t := range sc.services {
+		if t.Kind() == reflect.Interface && t.NumMethod() > 0 && t != closerType && !internalOnly(sc.services[t]) {
+			candidates = append(candidates, t)
+		}
+	}
+
+	warnings := make([]string, 0)
+	for _, root := range sc.sortedRegistrations() {
+		service := sc.services[root.Type][root.Name]
+		warnings = append(warnings, sc.captiveDependencies(root, service)...)
+
+		if len(service.Interfaces) == 0 {
+			continue
+		}
+
+		for _, candidate := range candidates {
+			if _, mapped := service.Interfaces[candidate]; mapped {
+				continue
+			}
+
+			if root.Type.Implements(candidate) {
+				warnings = append(warnings, fmt.Sprintf("'%s' implements '%s' but is not mapped to it, missing With[%s]()?",
+					root, candidate, candidate))
+			}
+		}
+	}
+
+	sort.Strings(warnings)
+	return warnings, err
+}
+
+// captiveDependencies returns a warning for every transient service injected into
+// the provided singleton registration. The caller must hold the lock.
+func (sc *ServiceContainer) captiveDependencies(root dependency, service *RegistrationService) []string {
+	if !service.IsSingleton || !service.FabricTags {
+		return nil
+	}
+
+	warnings := make([]string, 0)
+	for _, dep := range injectDependencies(service.Type) {
+		injected, _, _, _ := sc.findRegistration(dep.Type, dep.Name)
+		if injected == nil || injected.IsSingleton || injected.IsScoped {
+			continue
+		}
+
+		warnings = append(warnings, fmt.Sprintf("singleton '%s' depends on transient '%s', capturing a single instance of it",
+			root, dep))
+	}
+
+	return warnings
+}
+
+// CanResolve performs a dry-run resolution of T by walking its dependency graph and
+// checking that every transitive fabric:"inject" dependency is registered. Unlike
+// Resolve, it never invokes factories or lifecycle methods and has no side effects:
+// no instances are created, cached or initialized.
+//
+// It returns an error describing the first unsatisfiable dependency, or nil if T can
+// be resolved. This is the per-type counterpart to Validate.
+//
+// Example:
+//
+//	if err := CanResolve[*UserService](ctx, container); err != nil {
+//		t.Fatalf("UserService is not wired correctly: %v", err)
+//	}
+func CanResolve[T any](ctx context.Context, sc *ServiceContainer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	path := []dependency{{Type: typeKey[T]()}}
+	return sc.validateDependency(path, make(map[dependency]bool))
+}
+
+// sortedRegistrations returns the concrete type and name of every registration except
+// the default registrations of the container, sorted to produce deterministic results.
+func (sc *ServiceContainer) sortedRegistrations() []dependency {
+	roots := make([]dependency, 0)
+	for t, serviceMaps := range sc.services {
+		for name, service := range serviceMaps {
+			if service.Type == t && !service.internal {
+				roots = append(roots, dependency{Type: t, Name: name})
+			}
+		}
+	}
+
+	sort.Slice(roots, func(i, j int) bool {
+		return roots[i].String() < roots[j].String()
+	})
+
+	return roots
+}
+
+// internalOnly reports whether all registrations of a type are default registrations.
+func internalOnly(serviceMaps map[string]*RegistrationService) bool {
+	for _, service := range serviceMaps {
+		if !service.internal {
+			return false
+		}
+	}
+
+	return len(serviceMaps) > 0
+}
+
+// validateDependency recursively validates the last dependency of the provided path.
+// It returns an error describing the full path to the first unsatisfiable dependency,
+// or nil if the dependency and all of its transitive dependencies can be satisfied.
+func (sc *ServiceContainer) validateDependency(path []dependency, visiting map[dependency]bool) error {
+	current := path[len(path)-1]
+
+	service, _, _, err := sc.findRegistration(current.Type, current.Name)
+	if err != nil {
+		return fmt.Errorf("%s (%w)", formatDependencyPath(path), err)
+	}
+
+	if service == nil {
+		if current.Optional {
+			return nil
+		}
+		return fmt.Errorf("%s (not registered)", formatDependencyPath(path))
+	}
+
+	// Late dependencies are injected after construction and can not cause cycles
+	if current.Late {
+		return nil
+	}
+
+	if visiting[current] {
+		return fmt.Errorf("%s (circular dependency)", formatDependencyPath(path))
+	}
+
+	if !service.FabricTags || service.Type == nil {
+		return nil
+	}
+
+	visiting[current] = true
+	defer delete(visiting, current)
+
+	for _, dep := range injectDependencies(service.Type) {
+		if err := sc.validateDependency(append(path[:len(path):len(path)], dep), visiting); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lookupRegistration returns the registration and its resolved name for the provided
+// type and name, taking aliases and parent containers into account. The caller must
+// hold the container lock.
+func (sc *ServiceContainer) lookupRegistration(t reflect.Type, name string) (*RegistrationService, string, bool) {
+	resolvedName := sc.resolveAlias(t, name)
+	if service, exists := sc.services[t][resolvedName]; exists {
+		if _, missing := sc.missingCapability(service); missing {
+			return nil, "", false
+		}
+		return service, resolvedName, true
+	}
+
+	if sc.parent == nil {
+		return nil, "", false
+	}
+
+	sc.parent.mu.RLock()
+	defer sc.parent.mu.RUnlock()
+
+	return sc.parent.lookupRegistration(t, name)
+}
+
+// findRegistration returns the registration resolving the provided type and name like
+// lookupRegistration, falling back to registrations of assignable interfaces if interface
+// fallback is enabled. It is shared by resolution and validation, so both agree on which
+// services can be resolved. The returned type is the type the registration has been found
+// for, which differs from the provided type for registrations found via the fallback. The
+// caller must hold the container lock.
+func (sc *ServiceContainer) findRegistration(t reflect.Type, name string) (*RegistrationService, string, reflect.Type, error) {
+	if service, resolvedName, exists := sc.lookupRegistration(t, name); exists {
+		return service, resolvedName, t, nil
+	}
+
+	candidate, found, err := sc.assignableInterface(t, name)
+	if err != nil || !found {
+		return nil, "", nil, err
+	}
+
+	service, resolvedName, _ := sc.lookupRegistration(candidate, name)
+	return service, resolvedName, candidate, nil
+}
+
+// injectDependencies returns the dependencies declared via fabric:"inject" tags on
+// the struct fields of the provided type. Tags handled by custom processors are ignored.
+func injectDependencies(t reflect.Type) []dependency {
+	deps := make([]dependency, 0)
+	for _, plan := range fabricFields(t) {
+		if value, ok := injectTagValue(plan.tag); ok {
+			deps = append(deps, dependency{
+				Type:     plan.field.Type,
+				Name:     parseInjectName(value),
+				Late:     plan.flags.late,
+				Optional: plan.flags.optional || plan.flags.condition != "",
+			})
+		}
+	}
+
+	return deps
+}
+
+// formatDependencyPath joins the provided path into a human-readable chain.
+func formatDependencyPath(path []dependency) string {
+	parts := make([]string, 0, len(path))
+	for _, dep := range path {
+		parts = append(parts, dep.String())
+	}
+
+	return strings.Join(parts, " needs ")
+}
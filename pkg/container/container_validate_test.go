@@ -0,0 +1,132 @@
+package container
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type AuditService struct {
+	Agent *Agent `fabric:"inject"`
+}
+
+func TestValidateTransitiveDependencies(t *testing.T) {
+	sc := NewServiceContainer()
+
+	errs := &Errors{}
+	errs.Add(Register[*AuditService](sc))
+	errs.Add(Register[*Agent](sc))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	err := sc.Validate()
+	if err == nil {
+		t.Fatal("Expected validation to fail for missing transitive dependency")
+	}
+
+	expected := "*container.AuditService needs *container.Agent needs container.EncryptEngine (not registered)"
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error to contain %q, got %v", expected, err)
+	}
+
+	if err := Register[*EncryptService](sc, With[EncryptEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := sc.Validate(); err != nil {
+		t.Errorf("Expected validation to succeed, got %v", err)
+	}
+}
+
+func TestCanResolveWithoutSideEffects(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	called := false
+	errs := &Errors{}
+	errs.Add(Register[*Agent](sc, AsSingleton()))
+	errs.Add(Register[*LoggerService](sc,
+		With[LoggerEngine](),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			called = true
+			return &LoggerService{}, nil
+		})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := CanResolve[*Agent](ctx, sc); err == nil {
+		t.Error("Expected dry-run resolution to fail for missing dependency")
+	}
+
+	if err := Register[*EncryptService](sc, With[EncryptEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := CanResolve[*Agent](ctx, sc); err != nil {
+		t.Errorf("Expected dry-run resolution to succeed, got %v", err)
+	}
+
+	if called {
+		t.Error("Expected dry-run resolution not to invoke factories")
+	}
+
+	if len(sc.singletons) != 0 {
+		t.Error("Expected dry-run resolution not to cache singletons")
+	}
+}
+
+type ClosableLogger struct {
+	LoggerService
+}
+
+func (cl *ClosableLogger) Close() error {
+	return nil
+}
+
+func TestValidateWithWarnings(t *testing.T) {
+	sc := NewServiceContainer()
+
+	errs := &Errors{}
+	errs.Add(Register[*ClosableLogger](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	warnings, err := sc.ValidateWithWarnings()
+	if err != nil {
+		t.Fatalf("Expected validation to succeed, got %v", err)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "io.Closer") {
+		t.Errorf("Expected a single io.Closer warning, got %v", warnings)
+	}
+}
+
+func TestValidateWithWarningsCaptiveDependency(t *testing.T) {
+	sc := NewServiceContainer()
+
+	errs := &Errors{}
+	errs.Add(Register[*Agent](sc, AsSingleton()))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine](), AsSingleton()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	warnings, err := sc.ValidateWithWarnings()
+	if err != nil {
+		t.Fatalf("Expected validation to succeed, got %v", err)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "transient 'container.LoggerEngine'") {
+		t.Errorf("Expected a single captive dependency warning, got %v", warnings)
+	}
+}
@@ -0,0 +1,55 @@
+// Package containertest provides helpers for testing the wiring of a
+// container.ServiceContainer, replacing the Resolve-and-check boilerplate that
+// test suites would otherwise reimplement.
+//
+// Example:
+//
+//	func TestWiring(t *testing.T) {
+//		sc := app.NewContainer()
+//		containertest.WithStub[Database](sc, &FakeDatabase{})
+//
+//		service := containertest.AssertResolvable[*UserService](t, sc)
+//		containertest.AssertNotResolvable[*DebugServer](t, sc)
+//	}
+package containertest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mwantia/fabric/pkg/container"
+)
+
+// AssertResolvable resolves T from the container and returns the resolved value.
+// The test fails immediately if T can not be resolved.
+func AssertResolvable[T any](t testing.TB, sc *container.ServiceContainer) T {
+	t.Helper()
+
+	resolved, err := container.Resolve[T](t.Context(), sc)
+	if err != nil {
+		t.Fatalf("Expected '%s' to be resolvable, got error: %v", typeName[T](), err)
+	}
+
+	return resolved
+}
+
+// AssertNotResolvable fails the test if T can be resolved from the container.
+func AssertNotResolvable[T any](t testing.TB, sc *container.ServiceContainer) {
+	t.Helper()
+
+	if _, err := container.Resolve[T](t.Context(), sc); err == nil {
+		t.Fatalf("Expected '%s' not to be resolvable", typeName[T]())
+	}
+}
+
+// WithStub registers the provided stub as the unnamed singleton instance of T,
+// replacing any existing unnamed registration of T. It should be called before T
+// is resolved for the first time, since previously cached singletons are kept.
+func WithStub[T any](sc *container.ServiceContainer, stub T) error {
+	return container.Register[T](sc, container.WithInstance(stub))
+}
+
+// typeName returns the name of type T for use in failure messages.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
@@ -0,0 +1,38 @@
+package containertest
+
+import (
+	"testing"
+
+	"github.com/mwantia/fabric/pkg/container"
+)
+
+type Greeter interface {
+	Greet() string
+}
+
+type StubGreeter struct{}
+
+func (sg *StubGreeter) Greet() string { return "stub" }
+
+type GreetingService struct {
+	Greeter Greeter `fabric:"inject"`
+}
+
+func TestAssertResolvableWithStub(t *testing.T) {
+	sc := container.NewServiceContainer()
+
+	if err := container.Register[*GreetingService](sc); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	AssertNotResolvable[*GreetingService](t, sc)
+
+	if err := WithStub[Greeter](sc, &StubGreeter{}); err != nil {
+		t.Fatalf("Failed to register stub: %v", err)
+	}
+
+	service := AssertResolvable[*GreetingService](t, sc)
+	if greeting := service.Greeter.Greet(); greeting != "stub" {
+		t.Errorf("Expected stub greeting, got '%s'", greeting)
+	}
+}
@@ -0,0 +1,117 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// decorator wraps instances resolved for a type, either for a single registration
+// name or for all registrations of the type.
+type decorator struct {
+	// name is the registration name the decorator applies to, ignored if all is set
+	name string
+
+	// all indicates whether the decorator applies to every registration of the type
+	all bool
+
+	// fn wraps the provided instance and returns the decorated instance
+	fn func(context.Context, *ServiceContainer, any) (any, error)
+}
+
+// Decorate registers a decorator wrapping every instance resolved for the unnamed
+// registration of type T. See DecorateName for the ordering of decorators.
+//
+// Example:
+//
+//	Decorate(container, func(ctx context.Context, sc *ServiceContainer, inner Database) (Database, error) {
+//		return &TracingDatabase{inner: inner}, nil
+//	})
+func Decorate[T any](sc *ServiceContainer, fn func(context.Context, *ServiceContainer, T) (T, error)) {
+	DecorateName(sc, "", fn)
+}
+
+// DecorateName registers a decorator wrapping every instance resolved for type T with
+// the given registration name. Decorators run once per constructed instance and requested
+// type, so the decorated instance of a singleton is created once and cached, while
+// resolving the singleton via another type or name returns it without this decorator.
+//
+// Decorators run after all global and registration-specific middlewares: first every
+// decorator registered via DecorateAll, then every decorator registered via Decorate
+// or DecorateName, each in the order they were added. Lifecycle initialization and
+// cleanup apply to the undecorated instance. Decorators only apply when resolving T
+// itself, since the decorated instance is usually not assignable to the concrete type.
+func DecorateName[T any](sc *ServiceContainer, name string, fn func(context.Context, *ServiceContainer, T) (T, error)) {
+	sc.addDecorator(typeKey[T](), decorator{name: name, fn: typedDecorator(fn)})
+}
+
+// DecorateAll registers a decorator wrapping every instance resolved for type T,
+// regardless of its registration name. This allows wrapping a whole set of plugins
+// mapped to an interface, e.g. with panic recovery, without touching each registration.
+// See DecorateName for the ordering of decorators.
+//
+// Example:
+//
+//	DecorateAll(container, func(ctx context.Context, sc *ServiceContainer, inner Handler) (Handler, error) {
+//		return &RecoveringHandler{inner: inner}, nil
+//	})
+func DecorateAll[T any](sc *ServiceContainer, fn func(context.Context, *ServiceContainer, T) (T, error)) {
+	sc.addDecorator(typeKey[T](), decorator{all: true, fn: typedDecorator(fn)})
+}
+
+// typedDecorator converts a typed decorator function into an untyped one.
+func typedDecorator[T any](fn func(context.Context, *ServiceContainer, T) (T, error)) func(context.Context, *ServiceContainer, any) (any, error) {
+	return func(ctx context.Context, sc *ServiceContainer, instance any) (any, error) {
+		inner, ok := instance.(T)
+		if !ok {
+			return nil, fmt.Errorf("decorated value of type '%T' is not assignable to '%s'", instance, typeKey[T]())
+		}
+
+		return fn(ctx, sc, inner)
+	}
+}
+
+// addDecorator appends the decorator for the provided type.
+func (sc *ServiceContainer) addDecorator(key reflect.Type, d decorator) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	d.name = sc.normalizeName(d.name)
+
+	sc.decorators[key] = append(sc.decorators[key], d)
+}
+
+// decorate applies all decorators registered for the provided type and name to the
+// instance, running the decorators of DecorateAll before the named decorators.
+func (sc *ServiceContainer) decorate(ctx context.Context, key reflect.Type, name string, instance any) (any, error) {
+	sc.mu.RLock()
+	decorators := append([]decorator{}, sc.decorators[key]...)
+	sc.mu.RUnlock()
+
+	ordered := make([]decorator, 0, len(decorators))
+	for _, d := range decorators {
+		if d.all {
+			ordered = append(ordered, d)
+		}
+	}
+	for _, d := range decorators {
+		if !d.all && d.name == name {
+			ordered = append(ordered, d)
+		}
+	}
+
+	for _, d := range ordered {
+		decorated, err := d.fn(ctx, sc, instance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decorate '%s' with name '%s': %w", key, name, err)
+		}
+
+		if isNil(decorated) {
+			return nil, fmt.Errorf("decorator for '%s' returned no instance: %w", key, ErrNilInstance)
+		}
+
+		instance = decorated
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,137 @@
+package container
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+type RecordingLogger struct {
+	inner LoggerEngine
+	label string
+	order *[]string
+}
+
+func (rl *RecordingLogger) Debug(msg string, args ...any) {
+	*rl.order = append(*rl.order, rl.label)
+	rl.inner.Debug(msg, args...)
+}
+
+func recordingDecorator(label string, order *[]string) func(context.Context, *ServiceContainer, LoggerEngine) (LoggerEngine, error) {
+	return func(ctx context.Context, sc *ServiceContainer, inner LoggerEngine) (LoggerEngine, error) {
+		return &RecordingLogger{inner: inner, label: label, order: order}, nil
+	}
+}
+
+func TestDecorateAllOrdering(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, WithName[LoggerEngine]("console")))
+	errs.Add(Register[*NamedLogger](sc, WithName[LoggerEngine]("file"), namedLoggerFactory("file")))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	order := make([]string, 0)
+	DecorateName(sc, "console", recordingDecorator("console", &order))
+	DecorateAll(sc, recordingDecorator("all", &order))
+
+	loggers, err := ResolveAll[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve loggers: %v", err)
+	}
+
+	for _, logger := range loggers {
+		logger.Debug("message")
+	}
+
+	// The named decorator wraps the DecorateAll decorator, so it is called first
+	expected := []string{"console", "all", "all"}
+	if !slices.Equal(order, expected) {
+		t.Errorf("Expected decorator calls %v, got %v", expected, order)
+	}
+
+	if _, err := ResolveName[*LoggerService](ctx, sc, ""); err != nil {
+		t.Errorf("Expected concrete type to be resolvable without decoration: %v", err)
+	}
+}
+
+func TestDecorateSingletonLifecycle(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*CounterService](sc, With[CounterEngine](), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	decorations := 0
+	Decorate(sc, func(ctx context.Context, sc *ServiceContainer, inner CounterEngine) (CounterEngine, error) {
+		decorations++
+		return struct{ CounterEngine }{inner}, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		counter, err := Resolve[CounterEngine](ctx, sc)
+		if err != nil {
+			t.Fatalf("Failed to resolve counter: %v", err)
+		}
+
+		if counter.Count() != 1 {
+			t.Errorf("Expected undecorated instance to be initialized once, got %d", counter.Count())
+		}
+	}
+
+	if decorations != 1 {
+		t.Errorf("Expected singleton to be decorated once, got %d", decorations)
+	}
+}
+
+func TestDecorateSharedSingletonInBothOrders(t *testing.T) {
+	for _, interfaceFirst := range []bool{true, false} {
+		sc := NewServiceContainer()
+		ctx := t.Context()
+
+		if err := Register[*CounterService](sc, With[CounterEngine](), AsSingleton()); err != nil {
+			t.Fatalf("Failed to complete service registration: %v", err)
+		}
+
+		type decorated struct{ CounterEngine }
+		Decorate(sc, func(ctx context.Context, sc *ServiceContainer, inner CounterEngine) (CounterEngine, error) {
+			return &decorated{inner}, nil
+		})
+
+		var counter CounterEngine
+		var concrete *CounterService
+		var err error
+
+		if interfaceFirst {
+			if counter, err = Resolve[CounterEngine](ctx, sc); err == nil {
+				concrete, err = Resolve[*CounterService](ctx, sc)
+			}
+		} else {
+			if concrete, err = Resolve[*CounterService](ctx, sc); err == nil {
+				counter, err = Resolve[CounterEngine](ctx, sc)
+			}
+		}
+		if err != nil {
+			t.Fatalf("Failed to resolve counter (interface first: %t): %v", interfaceFirst, err)
+		}
+
+		wrapper, ok := counter.(*decorated)
+		if !ok {
+			t.Fatalf("Expected interface to be decorated (interface first: %t), got '%T'", interfaceFirst, counter)
+		}
+
+		if wrapper.CounterEngine != CounterEngine(concrete) || concrete.Count() != 1 {
+			t.Errorf("Expected decorator to wrap the shared singleton (interface first: %t)", interfaceFirst)
+		}
+
+		again, err := Resolve[CounterEngine](ctx, sc)
+		if err != nil || again != counter {
+			t.Errorf("Expected decorated view to be cached (interface first: %t): %v", interfaceFirst, err)
+		}
+	}
+}
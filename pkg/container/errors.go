@@ -5,6 +5,21 @@ import (
 	"sync"
 )
 
+// ErrNilInstance is returned when a factory or middleware produces a nil instance
+// without returning an error. Nil instances are never cached or injected.
+var ErrNilInstance = errors.New("resolved instance is nil")
+
+// ErrNotRegistered is returned when no registration exists for a requested type and name.
+var ErrNotRegistered = errors.New("registration not found")
+
+// ErrPanic is returned when a panic was recovered during resolution, which is only
+// done if panic recovery has been enabled via SetRecoverPanics.
+var ErrPanic = errors.New("panic during resolution")
+
+// ErrConstructTimeout is returned when a factory or lifecycle Init call exceeds the
+// construction timeout of its registration, configured via WithConstructTimeout.
+var ErrConstructTimeout = errors.New("construction timed out")
+
 // Errors is a thread-safe collection of errors that can be accumulated
 // and then joined into a single error. This is used internally by the
 // container for collecting multiple errors during operations like cleanup.
@@ -37,3 +52,27 @@ func (e *Errors) Errors() error {
 
 	return errors.Join(e.errors...)
 }
+
+// Len returns the number of accumulated errors. This method is thread-safe.
+func (e *Errors) Len() int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return len(e.errors)
+}
+
+// All returns a copy of all accumulated errors in the order they were added.
+// This method is thread-safe.
+func (e *Errors) All() []error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return append([]error{}, e.errors...)
+}
+
+// Unwrap returns a copy of all accumulated errors, following the convention of joined
+// errors. Since Errors is not an error itself, errors.Is and errors.As are applied to the
+// joined error returned by Errors, which traverses the same errors. This method is thread-safe.
+func (e *Errors) Unwrap() []error {
+	return e.All()
+}
@@ -0,0 +1,37 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorsInspection(t *testing.T) {
+	errs := &Errors{}
+	errs.Add(fmt.Errorf("failed to cleanup database: %w", ErrNilInstance))
+	errs.Add(nil)
+	errs.Add(fmt.Errorf("failed to resolve logger: %w", ErrNotRegistered))
+
+	if errs.Len() != 2 {
+		t.Fatalf("Expected 2 errors, got %d", errs.Len())
+	}
+
+	all := errs.All()
+	all[0] = nil
+	if errs.All()[0] == nil {
+		t.Error("Expected All to return a copy")
+	}
+
+	if len(errs.Unwrap()) != 2 {
+		t.Errorf("Expected Unwrap to return 2 errors, got %d", len(errs.Unwrap()))
+	}
+
+	err := errs.Errors()
+	if !errors.Is(err, ErrNotRegistered) || !errors.Is(err, ErrNilInstance) {
+		t.Error("Expected errors.Is to traverse the accumulated errors")
+	}
+
+	if errors.Is(err, ErrPanic) {
+		t.Error("Expected errors.Is not to match errors that were not added")
+	}
+}
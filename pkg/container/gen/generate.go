@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// service describes a struct with fabric tags a factory is generated for.
+type service struct {
+	Name   string
+	Fields []field
+}
+
+// field describes a settable struct field carrying a fabric tag.
+type field struct {
+	Name string
+	Tag  string
+
+	// Dependency is set for inject tags, which are resolved via typed calls instead of reflection
+	Dependency *dependency
+}
+
+// dependency describes the parsed tag of a field tagged with fabric:"inject" or
+// fabric:"inject:name", mirroring container.Dependency.
+type dependency struct {
+	Value     string
+	Name      string
+	Optional  bool
+	Condition string
+}
+
+// generate parses the Go files of the package in the provided directory, ignoring
+// tests and the output file, and returns the formatted source of the generated factories
+// for every struct declaring fabric tags. It returns nil if no struct declares fabric tags.
+func generate(dir string, output string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package directory: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	pkg := ""
+	services := make([]service, 0)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse '%s': %w", name, err)
+		}
+
+		pkg = file.Name.Name
+		services = append(services, fabricServices(file)...)
+	}
+
+	if len(services) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	buf := &bytes.Buffer{}
+	if err := factoryTemplate.Execute(buf, map[string]any{
+		"Package":  pkg,
+		"Services": services,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to render factories: %w", err)
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format factories: %w", err)
+	}
+
+	return source, nil
+}
+
+// fabricServices returns every non-generic struct declared in the file that has at least
+// one field with a fabric tag. Like reflective injection, only exported fields are
+// injected, while unexported fields are left untouched.
+func fabricServices(file *ast.File) []service {
+	services := make([]service, 0)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || typeSpec.TypeParams != nil {
+				continue
+			}
+
+			tagged := false
+			fields := make([]field, 0)
+			for _, f := range structType.Fields.List {
+				tag := fabricTag(f)
+				if tag == "" {
+					continue
+				}
+				tagged = true
+
+				for _, name := range fieldNames(f) {
+					if ast.IsExported(name) {
+						fields = append(fields, field{Name: name, Tag: tag, Dependency: parseDependency(tag)})
+					}
+				}
+			}
+
+			if tagged {
+				services = append(services, service{Name: typeSpec.Name.Name, Fields: fields})
+			}
+		}
+	}
+
+	return services
+}
+
+// fabricTag returns the value of the fabric tag of the provided field.
+func fabricTag(f *ast.Field) string {
+	if f.Tag == nil {
+		return ""
+	}
+
+	tag, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return ""
+	}
+
+	return reflect.StructTag(tag).Get("fabric")
+}
+
+// fieldNames returns the names of the provided field, using the type name for embedded fields.
+func fieldNames(f *ast.Field) []string {
+	if len(f.Names) > 0 {
+		names := make([]string, 0, len(f.Names))
+		for _, name := range f.Names {
+			names = append(names, name.Name)
+		}
+		return names
+	}
+
+	expr := f.Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return []string{t.Name}
+	case *ast.SelectorExpr:
+		return []string{t.Sel.Name}
+	}
+
+	return nil
+}
+
+// parseDependency parses a fabric tag like the container does during reflective injection
+// and returns the dependency of plain inject tags. It returns nil for tags handled by other
+// processors, processor chains and late injection, which are injected via reflection.
+func parseDependency(tag string) *dependency {
+	parts := strings.Split(tag, ",")
+	value := strings.TrimSpace(parts[0])
+
+	lower := strings.ToLower(value)
+	if strings.Contains(value, ";") || (lower != "inject" && !strings.HasPrefix(lower, "inject:")) {
+		return nil
+	}
+
+	dep := &dependency{Value: value}
+	if _, name, named := strings.Cut(value, ":"); named {
+		dep.Name = strings.TrimSpace(name)
+	}
+
+	for _, flag := range parts[1:] {
+		flag = strings.TrimSpace(flag)
+		switch strings.ToLower(flag) {
+		case "late":
+			return nil
+		case "optional":
+			dep.Optional = true
+		default:
+			if len(flag) > 3 && strings.EqualFold(flag[:3], "if=") {
+				dep.Condition = strings.TrimSpace(flag[3:])
+			}
+		}
+	}
+
+	return dep
+}
+
+// dependencyLiteral renders the container.Dependency literal for the provided field.
+func dependencyLiteral(f field) string {
+	parts := []string{"Field: " + strconv.Quote(f.Name), "Value: " + strconv.Quote(f.Dependency.Value)}
+	if f.Dependency.Name != "" {
+		parts = append(parts, "Name: "+strconv.Quote(f.Dependency.Name))
+	}
+	if f.Dependency.Optional {
+		parts = append(parts, "Optional: true")
+	}
+	if f.Dependency.Condition != "" {
+		parts = append(parts, "Condition: "+strconv.Quote(f.Dependency.Condition))
+	}
+
+	return "container.Dependency{" + strings.Join(parts, ", ") + "}"
+}
+
+var factoryTemplate = template.Must(template.New("factories").Funcs(template.FuncMap{
+	"quote":      strconv.Quote,
+	"dependency": dependencyLiteral,
+}).Parse(`// Code generated by github.com/mwantia/fabric/pkg/container/gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"context"
+
+	"github.com/mwantia/fabric/pkg/container"
+)
+
+func init() {
+{{- range .Services }}
+	container.RegisterGeneratedFactory(newFabric{{ .Name }})
+{{- end }}
+}
+{{ range .Services }}
+// newFabric{{ .Name }} creates *{{ .Name }} and injects its fabric tagged fields.
+func newFabric{{ .Name }}(ctx context.Context, sc *container.ServiceContainer) (*{{ .Name }}, error) {
+	instance := &{{ .Name }}{}
+{{- range .Fields }}
+{{- if .Dependency }}
+	if err := container.InjectDependency(ctx, sc, &instance.{{ .Name }}, {{ dependency . }}); err != nil {
+		return nil, err
+	}
+{{- else }}
+	if err := container.InjectField(ctx, sc, &instance.{{ .Name }}, {{ quote .Name }}, {{ quote .Tag }}); err != nil {
+		return nil, err
+	}
+{{- end }}
+{{- end }}
+
+	return instance, nil
+}
+{{ end -}}
+`))
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateUpToDate(t *testing.T) {
+	dir := filepath.Join("internal", "fixture")
+
+	source, err := generate(dir, "fabric_gen.go")
+	if err != nil {
+		t.Fatalf("Failed to generate factories: %v", err)
+	}
+
+	expected, err := os.ReadFile(filepath.Join(dir, "fabric_gen.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated factories: %v", err)
+	}
+
+	if !bytes.Equal(source, expected) {
+		t.Error("Expected generated factories to be up to date, run 'go generate ./...'")
+	}
+}
+
+func TestGenerateWithoutFabricTags(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "plain.go"), []byte("package plain\n\ntype Plain struct {\n\tName string `json:\"name\"`\n}\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	source, err := generate(dir, "fabric_gen.go")
+	if err != nil {
+		t.Fatalf("Failed to generate factories: %v", err)
+	}
+
+	if source != nil {
+		t.Errorf("Expected no factories for structs without fabric tags, got:\n%s", source)
+	}
+}
+
+func TestParseDependency(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected *dependency
+	}{
+		{tag: "inject", expected: &dependency{Value: "inject"}},
+		{tag: "inject:primary", expected: &dependency{Value: "inject:primary", Name: "primary"}},
+		{tag: "inject, optional", expected: &dependency{Value: "inject", Optional: true}},
+		{tag: "inject:cache,if=caching", expected: &dependency{Value: "inject:cache", Name: "cache", Condition: "caching"}},
+		{tag: "inject,late"},
+		{tag: "inject; validate"},
+		{tag: "name"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tag, func(t *testing.T) {
+			dep := parseDependency(test.tag)
+			if !reflect.DeepEqual(dep, test.expected) {
+				t.Errorf("Expected %+v, got %+v", test.expected, dep)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Code generated by github.com/mwantia/fabric/pkg/container/gen. DO NOT EDIT.
+
+package fixture
+
+import (
+	"context"
+
+	"github.com/mwantia/fabric/pkg/container"
+)
+
+func init() {
+	container.RegisterGeneratedFactory(newFabricBroken)
+	container.RegisterGeneratedFactory(newFabricChild)
+	container.RegisterGeneratedFactory(newFabricHandler)
+	container.RegisterGeneratedFactory(newFabricParent)
+	container.RegisterGeneratedFactory(newFabricUserService)
+}
+
+// newFabricBroken creates *Broken and injects its fabric tagged fields.
+func newFabricBroken(ctx context.Context, sc *container.ServiceContainer) (*Broken, error) {
+	instance := &Broken{}
+	if err := container.InjectDependency(ctx, sc, &instance.Missing, container.Dependency{Field: "Missing", Value: "inject:missing", Name: "missing"}); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// newFabricChild creates *Child and injects its fabric tagged fields.
+func newFabricChild(ctx context.Context, sc *container.ServiceContainer) (*Child, error) {
+	instance := &Child{}
+	if err := container.InjectField(ctx, sc, &instance.Parent, "Parent", "inject,late"); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// newFabricHandler creates *Handler and injects its fabric tagged fields.
+func newFabricHandler(ctx context.Context, sc *container.ServiceContainer) (*Handler, error) {
+	instance := &Handler{}
+	if err := container.InjectDependency(ctx, sc, &instance.Logger, container.Dependency{Field: "Logger", Value: "inject"}); err != nil {
+		return nil, err
+	}
+	if err := container.InjectDependency(ctx, sc, &instance.Primary, container.Dependency{Field: "Primary", Value: "inject:primary", Name: "primary"}); err != nil {
+		return nil, err
+	}
+	if err := container.InjectDependency(ctx, sc, &instance.Metrics, container.Dependency{Field: "Metrics", Value: "inject", Optional: true}); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// newFabricParent creates *Parent and injects its fabric tagged fields.
+func newFabricParent(ctx context.Context, sc *container.ServiceContainer) (*Parent, error) {
+	instance := &Parent{}
+	if err := container.InjectDependency(ctx, sc, &instance.Child, container.Dependency{Field: "Child", Value: "inject"}); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// newFabricUserService creates *UserService and injects its fabric tagged fields.
+func newFabricUserService(ctx context.Context, sc *container.ServiceContainer) (*UserService, error) {
+	instance := &UserService{}
+	if err := container.InjectDependency(ctx, sc, &instance.Logger, container.Dependency{Field: "Logger", Value: "inject"}); err != nil {
+		return nil, err
+	}
+	if err := container.InjectDependency(ctx, sc, &instance.Primary, container.Dependency{Field: "Primary", Value: "inject:primary", Name: "primary"}); err != nil {
+		return nil, err
+	}
+	if err := container.InjectDependency(ctx, sc, &instance.Metrics, container.Dependency{Field: "Metrics", Value: "inject", Optional: true}); err != nil {
+		return nil, err
+	}
+	if err := container.InjectField(ctx, sc, &instance.Name, "Name", "name"); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,68 @@
+// Package fixture contains services used to verify that the factories generated by the
+// fabric code generator behave exactly like reflective fabric tag injection.
+package fixture
+
+//go:generate go run github.com/mwantia/fabric/pkg/container/gen
+
+// Logger is a dependency resolved by type.
+type Logger interface {
+	Log(message string)
+}
+
+// ConsoleLogger implements Logger.
+type ConsoleLogger struct {
+	messages []string
+}
+
+func (cl *ConsoleLogger) Log(message string) {
+	cl.messages = append(cl.messages, message)
+}
+
+// Database is a dependency resolved by name.
+type Database interface {
+	Name() string
+}
+
+// PostgresDB implements Database.
+type PostgresDB struct {
+	name string
+}
+
+func (pg *PostgresDB) Name() string {
+	return pg.name
+}
+
+// Metrics is an optional dependency.
+type Metrics struct{}
+
+// UserService covers named, optional, name and untagged fields.
+type UserService struct {
+	Logger   Logger   `fabric:"inject"`
+	Primary  Database `fabric:"inject:primary"`
+	Metrics  *Metrics `fabric:"inject,optional"`
+	Name     string   `fabric:"name"`
+	Manual   string
+	internal *Metrics `fabric:"inject"`
+}
+
+// Parent and Child form a cycle broken by late injection.
+type Parent struct {
+	Child *Child `fabric:"inject"`
+}
+
+// Child depends on its Parent via late injection.
+type Child struct {
+	Parent *Parent `fabric:"inject,late"`
+}
+
+// Broken depends on a database that is never registered.
+type Broken struct {
+	Missing Database `fabric:"inject:missing"`
+}
+
+// Handler only depends on injected services, isolating the cost of injection.
+type Handler struct {
+	Logger  Logger   `fabric:"inject"`
+	Primary Database `fabric:"inject:primary"`
+	Metrics *Metrics `fabric:"inject,optional"`
+}
@@ -0,0 +1,163 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mwantia/fabric/pkg/container"
+)
+
+// modes contains the registration options selecting generated or reflective injection.
+var modes = map[string][]container.RegistrationOption{
+	"generated":  nil,
+	"reflective": {container.WithoutGeneratedFactory()},
+}
+
+// newContainer registers all fixture services using the provided injection mode.
+func newContainer(t testing.TB, opts []container.RegistrationOption) *container.ServiceContainer {
+	sc := container.NewServiceContainer()
+
+	errs := &container.Errors{}
+	errs.Add(container.Register[*ConsoleLogger](sc, container.With[Logger](), container.AsSingleton()))
+	errs.Add(container.Register[*PostgresDB](sc, container.WithName[Database]("primary"), container.WithInstance(&PostgresDB{name: "primary"})))
+	errs.Add(container.Register[*UserService](sc, append([]container.RegistrationOption{container.AsNamed("users")}, opts...)...))
+	errs.Add(container.Register[*Parent](sc, append([]container.RegistrationOption{container.AsSingleton()}, opts...)...))
+	errs.Add(container.Register[*Child](sc, append([]container.RegistrationOption{container.AsSingleton()}, opts...)...))
+	errs.Add(container.Register[*Broken](sc, opts...))
+	errs.Add(container.Register[*Handler](sc, opts...))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	return sc
+}
+
+func TestInjection(t *testing.T) {
+	for mode, opts := range modes {
+		t.Run(mode, func(t *testing.T) {
+			sc := newContainer(t, opts)
+			ctx := t.Context()
+
+			service, err := container.ResolveName[*UserService](ctx, sc, "users")
+			if err != nil {
+				t.Fatalf("Failed to resolve user service: %v", err)
+			}
+
+			if _, ok := service.Logger.(*ConsoleLogger); !ok {
+				t.Errorf("Expected logger to be injected, got %T", service.Logger)
+			}
+
+			if service.Primary == nil || service.Primary.Name() != "primary" {
+				t.Errorf("Expected named database to be injected, got %v", service.Primary)
+			}
+
+			if service.Metrics != nil {
+				t.Errorf("Expected optional metrics to be left nil, got %v", service.Metrics)
+			}
+
+			if service.Name != "users" {
+				t.Errorf("Expected name to be injected, got '%s'", service.Name)
+			}
+
+			if service.Manual != "" || service.internal != nil {
+				t.Error("Expected untagged and unexported fields to be left untouched")
+			}
+		})
+	}
+}
+
+func TestLateInjection(t *testing.T) {
+	for mode, opts := range modes {
+		t.Run(mode, func(t *testing.T) {
+			sc := newContainer(t, opts)
+			ctx := t.Context()
+
+			parent, err := container.Resolve[*Parent](ctx, sc)
+			if err != nil {
+				t.Fatalf("Failed to resolve parent: %v", err)
+			}
+
+			if parent.Child == nil || parent.Child.Parent != parent {
+				t.Error("Expected circular dependency to be completed via late injection")
+			}
+		})
+	}
+}
+
+func TestInjectionErrors(t *testing.T) {
+	messages := make(map[string]string)
+	for mode, opts := range modes {
+		sc := newContainer(t, opts)
+
+		_, err := container.Resolve[*Broken](t.Context(), sc)
+		if !errors.Is(err, container.ErrNotRegistered) {
+			t.Fatalf("Expected %s injection to fail with ErrNotRegistered, got %v", mode, err)
+		}
+
+		messages[mode] = err.Error()
+	}
+
+	if messages["generated"] != messages["reflective"] {
+		t.Errorf("Expected identical errors, got '%s' and '%s'", messages["generated"], messages["reflective"])
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for mode, opts := range modes {
+		t.Run(mode, func(t *testing.T) {
+			sc := newContainer(t, opts)
+
+			if err := sc.Validate(); err == nil {
+				t.Error("Expected validation to report the missing dependency of *Broken")
+			}
+		})
+	}
+}
+
+// replicaProcessor takes precedence over the inject processor for the primary database.
+type replicaProcessor struct{}
+
+func (rp *replicaProcessor) GetPriority() int { return 100 }
+
+func (rp *replicaProcessor) CanProcess(value string) bool { return value == "inject:primary" }
+
+func (rp *replicaProcessor) Process(ctx context.Context, sc *container.ServiceContainer, field reflect.StructField, value string) (any, error) {
+	return &PostgresDB{name: "replica"}, nil
+}
+
+func TestInjectionCustomProcessorPrecedence(t *testing.T) {
+	for mode, opts := range modes {
+		t.Run(mode, func(t *testing.T) {
+			sc := newContainer(t, opts)
+			sc.AddTagProcessor(&replicaProcessor{})
+
+			handler, err := container.Resolve[*Handler](t.Context(), sc)
+			if err != nil {
+				t.Fatalf("Failed to resolve handler: %v", err)
+			}
+
+			if handler.Primary == nil || handler.Primary.Name() != "replica" {
+				t.Errorf("Expected custom processor to inject the replica, got %v", handler.Primary)
+			}
+		})
+	}
+}
+
+func BenchmarkResolve(b *testing.B) {
+	for _, mode := range []string{"generated", "reflective"} {
+		b.Run(mode, func(b *testing.B) {
+			sc := newContainer(b, modes[mode])
+			ctx := b.Context()
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := container.Resolve[*Handler](ctx, sc); err != nil {
+					b.Fatalf("Failed to resolve handler: %v", err)
+				}
+			}
+		})
+	}
+}
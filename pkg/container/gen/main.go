@@ -0,0 +1,41 @@
+// Command gen generates typed factories for structs using fabric tags, allowing the
+// container to construct them without inspecting their fields via reflection. The
+// generated factories are registered during package initialization and used whenever
+// the pointer type of a struct is registered without a custom factory or instance.
+//
+// Usage within the package declaring the structs:
+//
+//	//go:generate go run github.com/mwantia/fabric/pkg/container/gen
+//
+// Fields tagged with fabric:"inject" or fabric:"inject:name" are resolved via typed calls,
+// with their tags parsed at generation time. Fields handled by other tag processors or
+// marked for late injection fall back to reflective injection, so the generated factories
+// inject fields exactly like reflective injection.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package to generate factories for")
+	output := flag.String("output", "fabric_gen.go", "name of the generated file within the package directory")
+	flag.Parse()
+
+	source, err := generate(*dir, *output)
+	if err != nil {
+		log.Fatalf("Failed to generate factories: %v", err)
+	}
+
+	if source == nil {
+		log.Printf("No structs with fabric tags found in '%s'", *dir)
+		return
+	}
+
+	if err := os.WriteFile(filepath.Join(*dir, *output), source, 0o644); err != nil {
+		log.Fatalf("Failed to write factories: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// generatedFactories contains the factories registered by generated code, indexed by type.
+var generatedFactories sync.Map
+
+// RegisterGeneratedFactory registers a typed factory for T that replaces the reflective
+// fabric tag injection whenever T is registered without a custom factory or instance.
+// It is intended to be called from code emitted by the fabric code generator
+// (github.com/mwantia/fabric/pkg/container/gen), which constructs services without
+// inspecting their struct fields via reflection and resolves fabric:"inject" fields via
+// typed calls (see InjectDependency):
+//
+//	//go:generate go run github.com/mwantia/fabric/pkg/container/gen
+//
+// Factories must be registered before the types are registered with a container, which
+// generated code ensures by registering them during package initialization. Generated
+// factories can be bypassed per registration via WithoutGeneratedFactory.
+func RegisterGeneratedFactory[T any](factory func(context.Context, *ServiceContainer) (T, error)) {
+	generatedFactories.Store(typeKey[T](), RegistrationFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		instance, err := factory(ctx, sc)
+		if err != nil {
+			return nil, withConstructedType(typeKey[T](), err)
+		}
+
+		return instance, nil
+	}))
+}
+
+// generatedFactory returns the generated factory registered for the provided type.
+func generatedFactory(key reflect.Type) (RegistrationFactory, bool) {
+	factory, exists := generatedFactories.Load(key)
+	if !exists {
+		return nil, false
+	}
+
+	return factory.(RegistrationFactory), true
+}
+
+// InjectField populates the target field of a struct being constructed by a generated
+// factory, using the field name and the raw value of its fabric tag. The field is
+// processed exactly like during reflective injection, including tag processor chains,
+// as well as the optional and late flags, so generated factories match the behavior of
+// reflective injection. Generated code only uses it for fields InjectDependency can not
+// handle, such as custom tag processors and late injection.
+//
+// Example:
+//
+//	if err := InjectField(ctx, sc, &service.Logger, "Logger", "inject"); err != nil {
+//		return nil, err
+//	}
+func InjectField[F any](ctx context.Context, sc *ServiceContainer, target *F, field string, tag string) error {
+	value, flags := parseFabricTag(tag)
+	if value == "" {
+		return nil
+	}
+
+	structField := reflect.StructField{
+		Name: field,
+		Type: typeKey[F](),
+		Tag:  reflect.StructTag("fabric:" + strconv.Quote(tag)),
+	}
+	fieldVal := reflect.ValueOf(target).Elem()
+
+	if session, ok := sessionFromContext(ctx); ok && flags.late {
+		session.deferInjection(structField, fieldVal, value, flags)
+		return nil
+	}
+
+	return injectField(ctx, sc, structField, fieldVal, value, flags)
+}
+
+// Dependency describes a field tagged with fabric:"inject" or fabric:"inject:name" whose
+// tag has been parsed by the fabric code generator. It is intended to be created by
+// generated code only.
+type Dependency struct {
+	// Field is the name of the struct field
+	Field string
+
+	// Value is the inject value of the tag, such as "inject:primary"
+	Value string
+
+	// Name is the registration name parsed from Value, empty for unnamed injection
+	Name string
+
+	// Optional leaves the field at its zero value if the dependency is not registered
+	Optional bool
+
+	// Condition names the condition that must be met for the field to be injected
+	Condition string
+}
+
+// InjectDependency populates the target field of a struct being constructed by a generated
+// factory with the dependency described by dep, resolving it via ResolveName without
+// reflection. Errors match those of reflective injection. If a custom tag processor takes
+// precedence over the inject processor for the tag, the field is injected via InjectField
+// instead, like during reflective injection. It is intended to be called by generated code only.
+//
+// Example:
+//
+//	if err := InjectDependency(ctx, sc, &service.Primary, Dependency{Field: "Primary", Value: "inject:primary", Name: "primary"}); err != nil {
+//		return nil, err
+//	}
+func InjectDependency[F any](ctx context.Context, sc *ServiceContainer, target *F, dep Dependency) error {
+	if processor, ok := sc.tagProcessor.processorFor(dep.Value); !ok || !isInjectProcessor(processor) {
+		tag := dep.Value
+		if dep.Optional {
+			tag += ",optional"
+		}
+		if dep.Condition != "" {
+			tag += ",if=" + dep.Condition
+		}
+		return InjectField(ctx, sc, target, dep.Field, tag)
+	}
+
+	if dep.Condition != "" {
+		met, err := sc.conditionMet(ctx, dep.Condition)
+		if err != nil {
+			return wrapFieldError(reflect.StructField{Name: dep.Field, Type: typeKey[F]()}, err)
+		}
+		if !met {
+			return nil
+		}
+	}
+
+	resolved, err := ResolveName[F](ctx, sc, dep.Name)
+	if err != nil {
+		// Only skip optional fields if the field's own dependency is missing
+		if dep.Optional && errors.Is(err, ErrNotRegistered) && !sc.isRegistered(typeKey[F](), dep.Name) {
+			return nil
+		}
+
+		field := reflect.StructField{Name: dep.Field, Type: typeKey[F]()}
+		return wrapFieldError(field, injectError(sc, field, dep.Name, err))
+	}
+
+	*target = resolved
+	return nil
+}
+
+// isInjectProcessor reports whether the provided processor is the default inject processor.
+func isInjectProcessor(processor TagProcessor) bool {
+	_, ok := processor.(*InjectTagProcessor)
+	return ok
+}
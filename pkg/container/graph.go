@@ -0,0 +1,122 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// GraphNode describes a single service resolved while building a resolution graph
+// via ResolveGraph, together with the dependencies resolved to construct it.
+type GraphNode struct {
+	// Type is the type the service was requested with
+	Type reflect.Type
+
+	// Name is the registration name the service was resolved with
+	Name string
+
+	// Lifetime is the lifetime of the registration: "singleton", "scoped" or "transient"
+	Lifetime string
+
+	// Cached indicates whether the instance was returned from the cache instead of being constructed
+	Cached bool
+
+	// Dependencies contains the services resolved while constructing this service
+	Dependencies []*GraphNode
+}
+
+// String renders the node and its dependencies as an indented tree.
+func (gn *GraphNode) String() string {
+	sb := &strings.Builder{}
+	gn.write(sb, 0)
+
+	return sb.String()
+}
+
+// write renders the node at the provided depth into the builder.
+func (gn *GraphNode) write(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString(dependency{Type: gn.Type, Name: gn.Name}.String())
+	fmt.Fprintf(sb, " (%s", gn.Lifetime)
+	if gn.Cached {
+		sb.WriteString(", cached")
+	}
+	sb.WriteString(")\n")
+
+	for _, dep := range gn.Dependencies {
+		dep.write(sb, depth+1)
+	}
+}
+
+// graphContextKey is the context key used to store the node currently being constructed.
+type graphContextKey struct{}
+
+// graphFrame stores the node currently being constructed and the mutex guarding all
+// nodes of the graph, since factories may resolve dependencies concurrently.
+type graphFrame struct {
+	mu   *sync.Mutex
+	node *GraphNode
+}
+
+// ResolveGraph resolves T and returns a tree describing every service resolved to
+// satisfy it, including its type, name, lifetime and whether it came from the cache.
+// Dependencies of cached services are not listed, since they were not resolved again.
+// Services provided via ResolveWith overrides are not recorded.
+//
+// This is intended for debugging, e.g. investigating why a service is constructed.
+//
+// Example:
+//
+//	graph, err := ResolveGraph[*UserService](ctx, container)
+//	if err == nil {
+//		fmt.Print(graph)
+//	}
+func ResolveGraph[T any](ctx context.Context, sc *ServiceContainer) (*GraphNode, error) {
+	root := &GraphNode{}
+	ctx = context.WithValue(ctx, graphContextKey{}, &graphFrame{mu: &sync.Mutex{}, node: root})
+
+	if _, err := Resolve[T](ctx, sc); err != nil {
+		return nil, err
+	}
+
+	if len(root.Dependencies) == 0 {
+		return nil, fmt.Errorf("no resolution recorded for '%s'", typeKey[T]())
+	}
+
+	return root.Dependencies[0], nil
+}
+
+// recordGraphNode appends a node for the provided registration to the node currently
+// being constructed, if a resolution graph is being recorded. It returns the new node
+// and a context recording nested resolutions as its dependencies.
+func recordGraphNode(ctx context.Context, key reflect.Type, name string, service *RegistrationService) (*GraphNode, context.Context) {
+	frame, ok := ctx.Value(graphContextKey{}).(*graphFrame)
+	if !ok {
+		return nil, ctx
+	}
+
+	lifetime := "transient"
+	switch {
+	case service.IsSingleton:
+		lifetime = "singleton"
+	case service.IsScoped:
+		lifetime = "scoped"
+	}
+
+	node := &GraphNode{Type: key, Name: name, Lifetime: lifetime}
+
+	frame.mu.Lock()
+	frame.node.Dependencies = append(frame.node.Dependencies, node)
+	frame.mu.Unlock()
+
+	return node, context.WithValue(ctx, graphContextKey{}, &graphFrame{mu: frame.mu, node: node})
+}
+
+// markCached marks the node as returned from the cache, if a graph is being recorded.
+func (gn *GraphNode) markCached() {
+	if gn != nil {
+		gn.Cached = true
+	}
+}
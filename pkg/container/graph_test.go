@@ -0,0 +1,50 @@
+package container
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveGraph(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*CounterAgent](sc))
+	errs.Add(Register[*CounterService](sc, AsSingleton()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	graph, err := ResolveGraph[*CounterAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve graph: %v", err)
+	}
+
+	if graph.Type != typeKey[*CounterAgent]() || graph.Lifetime != "transient" || graph.Cached {
+		t.Errorf("Unexpected root node: %+v", graph)
+	}
+
+	if len(graph.Dependencies) != 1 {
+		t.Fatalf("Expected 1 dependency, got %d", len(graph.Dependencies))
+	}
+
+	counter := graph.Dependencies[0]
+	if counter.Type != typeKey[*CounterService]() || counter.Lifetime != "singleton" || counter.Cached {
+		t.Errorf("Unexpected dependency node: %+v", counter)
+	}
+
+	graph, err = ResolveGraph[*CounterAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve graph: %v", err)
+	}
+
+	if !graph.Dependencies[0].Cached {
+		t.Error("Expected singleton dependency to be cached on second resolution")
+	}
+
+	if rendered := graph.String(); !strings.Contains(rendered, "  *container.CounterService (singleton, cached)") {
+		t.Errorf("Unexpected rendered graph:\n%s", rendered)
+	}
+}
@@ -0,0 +1,126 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+var (
+	// responseWriterType is the reflect.Type of http.ResponseWriter
+	responseWriterType = reflect.TypeOf((*http.ResponseWriter)(nil)).Elem()
+
+	// requestType is the reflect.Type of *http.Request
+	requestType = reflect.TypeOf((*http.Request)(nil))
+)
+
+// handlerOptions contains the configuration of a handler created via Handler.
+type handlerOptions struct {
+	// perRequest resolves the dependencies for every request within a new scope
+	perRequest bool
+}
+
+// HandlerOption is a function type used to configure handlers created via Handler.
+type HandlerOption func(*handlerOptions)
+
+// PerRequest configures a handler to resolve its dependencies for every request instead
+// of once when the handler is created. Each request runs within its own scope (see
+// CreateScope), bound to the request context and closed once the handler has returned,
+// so scoped services are created per request and transient services are not shared.
+func PerRequest() HandlerOption {
+	return func(ho *handlerOptions) {
+		ho.perRequest = true
+	}
+}
+
+// Handler creates a standard HTTP handler from a function whose first two parameters are
+// http.ResponseWriter and *http.Request, followed by any number of dependencies resolved
+// from the container by type. Parameters of type context.Context receive the request
+// context. The function may return an error, which is answered with a generic internal
+// server error response. This removes the boilerplate of a handler struct with injected
+// fields for simple handlers.
+//
+// By default, dependencies are resolved once when the handler is created, so resolution
+// errors are returned immediately and requests do not pay for resolution. Use PerRequest
+// to resolve dependencies for every request within a request scope instead, which is
+// required for scoped dependencies. Failed per-request resolutions are answered with a
+// generic internal server error response.
+//
+// Example:
+//
+//	handler, err := container.Handler(ctx, func(w http.ResponseWriter, r *http.Request, users *UserService) error {
+//		user, err := users.GetUser(r.URL.Query().Get("id"))
+//		if err != nil {
+//			return err
+//		}
+//		return json.NewEncoder(w).Encode(user)
+//	})
+//	mux.Handle("/users", handler)
+func (sc *ServiceContainer) Handler(ctx context.Context, fn any, options ...HandlerOption) (http.HandlerFunc, error) {
+	val := reflect.ValueOf(fn)
+	if !val.IsValid() || val.Kind() != reflect.Func || val.IsNil() {
+		return nil, fmt.Errorf("handler must be a non-nil function, got %T", fn)
+	}
+
+	fnType := val.Type()
+	if fnType.NumIn() < 2 || fnType.In(0) != responseWriterType || fnType.In(1) != requestType {
+		return nil, fmt.Errorf("handler '%T' must accept http.ResponseWriter and *http.Request as first parameters", fn)
+	}
+
+	if fnType.NumOut() > 1 || (fnType.NumOut() == 1 && fnType.Out(0) != errorType) {
+		return nil, fmt.Errorf("handler '%T' may only return an error", fn)
+	}
+
+	opts := &handlerOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	if opts.perRequest {
+		return func(w http.ResponseWriter, r *http.Request) {
+			scope := sc.CreateScope(r.Context())
+			defer func() {
+				_ = scope.Close(context.WithoutCancel(r.Context()))
+			}()
+
+			args, err := scope.resolveArguments(r.Context(), fnType, 2)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			callHandler(val, args, w, r)
+		}, nil
+	}
+
+	bound, err := sc.resolveArguments(ctx, fnType, 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create handler '%T': %w", fn, err)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		args := append([]reflect.Value{}, bound...)
+
+		// Context parameters receive the request context instead of the creation context
+		for i := 2; i < fnType.NumIn(); i++ {
+			if fnType.In(i) == contextType {
+				requestCtx := r.Context()
+				args[i] = reflect.ValueOf(&requestCtx).Elem()
+			}
+		}
+
+		callHandler(val, args, w, r)
+	}, nil
+}
+
+// callHandler calls the handler function with the provided response writer, request and
+// resolved dependencies, answering a returned error with an internal server error.
+func callHandler(fn reflect.Value, args []reflect.Value, w http.ResponseWriter, r *http.Request) {
+	args[0] = reflect.ValueOf(&w).Elem()
+	args[1] = reflect.ValueOf(r)
+
+	if err := lastError(fn.Call(args)); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,123 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serve(handler http.HandlerFunc) int {
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	return recorder.Code
+}
+
+func TestHandlerResolvesAtCreation(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*NamedLogger](sc, namedLoggerFactory("bound")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	seen := make([]*NamedLogger, 0)
+	handler, err := sc.Handler(ctx, func(w http.ResponseWriter, r *http.Request, ctx context.Context, logger *NamedLogger) error {
+		if ctx != r.Context() {
+			t.Error("Expected context parameter to receive the request context")
+		}
+
+		seen = append(seen, logger)
+		if len(seen) > 1 {
+			return errors.New("failed")
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to create handler: %v", err)
+	}
+
+	if code := serve(handler); code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, code)
+	}
+
+	if code := serve(handler); code != http.StatusInternalServerError {
+		t.Errorf("Expected returned error to respond with status %d, got %d", http.StatusInternalServerError, code)
+	}
+
+	if len(seen) != 2 || seen[0] != seen[1] || seen[0].name != "bound" {
+		t.Error("Expected dependencies to be resolved once at creation")
+	}
+}
+
+func TestHandlerPerRequest(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*CounterService](sc, AsScoped()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := sc.Handler(ctx, func(w http.ResponseWriter, r *http.Request, counter *CounterService) {}); err == nil {
+		t.Error("Expected scoped dependency to fail outside of a request scope")
+	}
+
+	seen := make([]*CounterService, 0)
+	handler, err := sc.Handler(ctx, func(w http.ResponseWriter, r *http.Request, counter *CounterService) {
+		seen = append(seen, counter)
+	}, PerRequest())
+	if err != nil {
+		t.Fatalf("Failed to create handler: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := serve(handler); code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, code)
+		}
+	}
+
+	if len(seen) != 2 || seen[0] == seen[1] {
+		t.Fatal("Expected dependencies to be resolved per request")
+	}
+
+	for _, counter := range seen {
+		if counter.inits != 1 || counter.cleanups != 1 {
+			t.Errorf("Expected scoped dependency to be cleaned up after the request, got %d inits and %d cleanups", counter.inits, counter.cleanups)
+		}
+	}
+}
+
+func TestHandlerValidation(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	invalid := []any{
+		nil,
+		"handler",
+		func(r *http.Request, w http.ResponseWriter) {},
+		func(w http.ResponseWriter, r *http.Request) int { return 0 },
+		func(w http.ResponseWriter, r *http.Request, logger LoggerEngine) {},
+	}
+
+	for _, fn := range invalid {
+		if _, err := sc.Handler(ctx, fn); err == nil {
+			t.Errorf("Expected handler '%T' to be rejected", fn)
+		}
+	}
+
+	if err := Register[*NamedLogger](sc, WithName[LoggerEngine]("missing")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	handler, err := sc.Handler(ctx, func(w http.ResponseWriter, r *http.Request, logger LoggerEngine) {}, PerRequest())
+	if err != nil {
+		t.Fatalf("Failed to create handler: %v", err)
+	}
+
+	if code := serve(handler); code != http.StatusInternalServerError {
+		t.Errorf("Expected failed resolution to respond with status %d, got %d", http.StatusInternalServerError, code)
+	}
+}
@@ -1,10 +1,128 @@
 package container
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
 
 // typeKey returns the reflect.Type for type T, handling both concrete types
 // and interfaces correctly. This is used internally by the container for
-// type-based lookups and service registration.
+// type-based lookups and service registration. It is not cached, since
+// reflect.TypeFor is already cheaper than any map lookup keyed by T.
 func typeKey[T any]() reflect.Type {
-	return reflect.TypeOf((*T)(nil)).Elem()
+	return reflect.TypeFor[T]()
+}
+
+// isNil reports whether the provided value is nil, including typed nil values
+// such as nil pointers, maps, slices, channels, functions and interfaces.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return val.IsNil()
+	}
+
+	return false
+}
+
+// As reports whether the provided instance satisfies T and returns it as T. This is a
+// convenience for applying optional interfaces to instances, such as flushing every
+// singleton that is also a Flusher, and pairs well with ForEachSingleton. A nil
+// instance never satisfies T.
+//
+// Example:
+//
+//	err := container.ForEachSingleton(func(t reflect.Type, name string, instance any) error {
+//		if flusher, ok := As[Flusher](instance); ok {
+//			return flusher.Flush()
+//		}
+//		return nil
+//	})
+func As[T any](instance any) (T, bool) {
+	typed, ok := instance.(T)
+	return typed, ok
+}
+
+// nameContextKey is the context key used to store the requested registration name.
+type nameContextKey struct{}
+
+// ContextWithName returns a copy of the context carrying the provided registration name.
+// The container sets the name before calling a factory, so a single factory serving
+// multiple named registrations can read it via NameFromContext.
+func ContextWithName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, nameContextKey{}, name)
+}
+
+// NameFromContext returns the registration name a factory is being invoked for.
+// It returns an empty string if the context carries no name.
+//
+// Example:
+//
+//	shardFactory := AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+//		return NewShardConnection(NameFromContext(ctx)), nil
+//	})
+//
+//	Register[*ShardConnection](container, AsNamed("shard-1"), shardFactory)
+//	Register[*ShardConnection](container, AsNamed("shard-2"), shardFactory)
+func NameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(nameContextKey{}).(string)
+	return name
+}
+
+// requestedTypeContextKey is the context key used to store the requested type.
+type requestedTypeContextKey struct{}
+
+// RequestedTypeFromContext returns the type a factory is being invoked for, which is the
+// interface the caller requested when a registration is resolved via one of its mapped
+// interfaces, or the concrete type otherwise. A factory of a registration mapped to
+// multiple interfaces can use it to tailor the returned instance, e.g. returning a
+// restricted view. It returns nil if the context carries no type.
+//
+// Singletons and scoped services are cached per registration, so their factory is only
+// invoked for the type requested first. Tailored instances therefore require transient
+// registrations.
+//
+// Example:
+//
+//	Register[*Store](container, With[Reader](), With[Writer](),
+//		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+//			store := NewStore()
+//			if RequestedTypeFromContext(ctx) == reflect.TypeFor[Reader]() {
+//				return &ReadOnlyStore{store}, nil
+//			}
+//			return store, nil
+//		}))
+func RequestedTypeFromContext(ctx context.Context) reflect.Type {
+	requested, _ := ctx.Value(requestedTypeContextKey{}).(reflect.Type)
+	return requested
+}
+
+// freshContextKey is the context key used to disable instance caching.
+type freshContextKey struct{}
+
+// WithFreshInstances returns a copy of the context that forces every service resolved
+// with it, including all of its recursive dependencies, to be constructed anew as if
+// registered transient. Cached singletons and scoped services are neither returned nor
+// replaced, so the cache is left untouched. This overrides IsSingleton and IsScoped for
+// resolutions using this context only. Registrations of pre-created instances (see
+// WithInstance) still return their instance.
+//
+// This is useful for sandboxed or snapshot computations, such as building an isolated
+// service tree for a background job.
+//
+// Example:
+//
+//	job, err := Resolve[*ReportJob](container.WithFreshInstances(ctx), sc)
+func WithFreshInstances(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshContextKey{}, true)
+}
+
+// freshInstances reports whether the context disables instance caching.
+func freshInstances(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshContextKey{}).(bool)
+	return fresh
 }
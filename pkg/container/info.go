@@ -0,0 +1,49 @@
+package container
+
+import "reflect"
+
+// ContainerInfo is a read-only view of a container, allowing services to inspect its
+// wiring without being able to register or resolve services. Every container registers
+// its own view, which can be resolved or injected like any other service and is a safer
+// alternative to injecting the *ServiceContainer itself.
+//
+// Example:
+//
+//	type Diagnostics struct {
+//		Info ContainerInfo `fabric:"inject"`
+//	}
+//
+//	func (d *Diagnostics) HasMetrics() bool {
+//		return slices.Contains(d.Info.Capabilities(), "metrics")
+//	}
+type ContainerInfo interface {
+	// IsRegistered reports whether an active registration exists for the provided type
+	// and name, taking aliases and parent containers into account
+	IsRegistered(t reflect.Type, name string) bool
+
+	// Registrations returns the registration info of every service registered directly
+	// in the container, see ServiceContainer.Registrations
+	Registrations() []RegistrationInfo
+
+	// Capabilities returns the sorted names of all capabilities active in the container,
+	// see ServiceContainer.Capabilities
+	Capabilities() []string
+}
+
+// containerInfo implements ContainerInfo by delegating to the read-only methods of the
+// wrapped container, which is not accessible from outside the package.
+type containerInfo struct {
+	sc *ServiceContainer
+}
+
+func (ci *containerInfo) IsRegistered(t reflect.Type, name string) bool {
+	return ci.sc.isRegistered(t, name)
+}
+
+func (ci *containerInfo) Registrations() []RegistrationInfo {
+	return ci.sc.Registrations()
+}
+
+func (ci *containerInfo) Capabilities() []string {
+	return ci.sc.Capabilities()
+}
@@ -0,0 +1,106 @@
+package container
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+type InfoAgent struct {
+	Info ContainerInfo `fabric:"inject"`
+}
+
+func TestContainerInfoInjection(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, WithName[LoggerEngine]("console")))
+	errs.Add(Register[*InfoAgent](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+	sc.EnableCapability("metrics")
+
+	agent, err := Resolve[*InfoAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	info := agent.Info
+	if !info.IsRegistered(typeKey[LoggerEngine](), "console") || info.IsRegistered(typeKey[EncryptEngine](), "") {
+		t.Error("Expected view to report registrations of the container")
+	}
+
+	if !slices.Equal(info.Capabilities(), []string{"metrics"}) {
+		t.Errorf("Expected capability 'metrics', got %v", info.Capabilities())
+	}
+
+	if len(info.Registrations()) != len(sc.Registrations()) {
+		t.Errorf("Expected view to list the registrations of the container")
+	}
+}
+
+func TestContainerInfoIsReadOnly(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	info, err := Resolve[ContainerInfo](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve container info: %v", err)
+	}
+
+	if _, ok := any(info).(*ServiceContainer); ok {
+		t.Error("Expected view not to expose the container")
+	}
+
+	if _, ok := any(info).(interface {
+		Cleanup(context.Context) error
+	}); ok {
+		t.Error("Expected view not to allow cleaning up the container")
+	}
+
+	for _, registration := range info.Registrations() {
+		if registration.Type == typeKey[*LoggerService]() {
+			delete(registration.Interfaces, typeKey[LoggerEngine]())
+		}
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Errorf("Expected registrations to be unaffected by the view: %v", err)
+	}
+
+	if IsInstantiated[*LoggerService](sc, "") {
+		t.Error("Expected view not to construct services")
+	}
+}
+
+func TestContainerInfoPerChild(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	child := sc.CreateChild()
+	if err := Register[*LoggerService](child, With[LoggerEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	parentInfo, err := Resolve[ContainerInfo](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve container info: %v", err)
+	}
+
+	childInfo, err := Resolve[ContainerInfo](ctx, child)
+	if err != nil {
+		t.Fatalf("Failed to resolve container info: %v", err)
+	}
+
+	logger := typeKey[LoggerEngine]()
+	if parentInfo.IsRegistered(logger, "") || !childInfo.IsRegistered(logger, "") {
+		t.Error("Expected every container to provide its own view")
+	}
+}
@@ -0,0 +1,95 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SetInterfaceFallback controls whether resolving an interface type without a matching
+// registration falls back to registrations of other interfaces assignable to it. This
+// allows resolving a narrower interface, such as io.Reader, from a registration mapped
+// to an interface embedding it, such as io.ReadWriter. Resolution fails with an error if
+// multiple distinct registrations are candidates. Disabled by default, so interfaces are
+// only resolved by exact match. Validate and CanResolve apply the fallback as well. Child
+// containers and scopes inherit the setting of their parent at creation and also fall back
+// to interfaces registered in their parents.
+//
+// Example:
+//
+//	Register[*File](container, With[io.ReadWriter]())
+//	container.SetInterfaceFallback(true)
+//
+//	reader, err := Resolve[io.Reader](ctx, container)
+func (sc *ServiceContainer) SetInterfaceFallback(enabled bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.interfaceFallback = enabled
+}
+
+// assignableInterface returns the registered interface type assignable to the requested
+// interface type that has a registration with the given name, if interface fallback is
+// enabled. Interfaces registered in this container and its parents are considered, so child
+// containers and scopes fall back like their root. Interfaces mapped to the same registration
+// are not considered ambiguous. The caller must hold the lock.
+func (sc *ServiceContainer) assignableInterface(key reflect.Type, name string) (reflect.Type, bool, error) {
+	if key.Kind() != reflect.Interface || !sc.interfaceFallback {
+		return nil, false, nil
+	}
+
+	types := make(map[reflect.Type]bool)
+	sc.assignableTypes(key, types)
+
+	candidates := make(map[*RegistrationService]reflect.Type)
+	for t := range types {
+		// Registrations are looked up like exact matches, so child registrations shadow their parent
+		service, _, exists := sc.lookupRegistration(t, name)
+		if !exists || service.internal {
+			continue
+		}
+
+		// Prefer the lexically smallest interface for registrations reachable via multiple interfaces
+		if existing, found := candidates[service]; !found || t.String() < existing.String() {
+			candidates[service] = t
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, false, nil
+	}
+
+	names := make([]string, 0, len(candidates))
+	var candidate reflect.Type
+	for _, t := range candidates {
+		names = append(names, fmt.Sprintf("'%s'", t))
+		candidate = t
+	}
+
+	if len(candidates) > 1 {
+		sort.Strings(names)
+		return nil, false, fmt.Errorf("ambiguous resolution of '%s' and name '%s': assignable from %s", key, name, strings.Join(names, ", "))
+	}
+
+	return candidate, true, nil
+}
+
+// assignableTypes collects the interface types registered in this container and its parents
+// that are assignable to the provided interface type. The caller must hold the lock.
+func (sc *ServiceContainer) assignableTypes(key reflect.Type, types map[reflect.Type]bool) {
+	for t := range sc.services {
+		if t != key && t.Kind() == reflect.Interface && t.Implements(key) {
+			types[t] = true
+		}
+	}
+
+	if sc.parent == nil {
+		return
+	}
+
+	sc.parent.mu.RLock()
+	defer sc.parent.mu.RUnlock()
+
+	sc.parent.assignableTypes(key, types)
+}
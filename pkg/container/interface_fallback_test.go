@@ -0,0 +1,150 @@
+package container
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+type ReadWriterService struct{}
+
+func (rws *ReadWriterService) Read(p []byte) (int, error)  { return 0, io.EOF }
+func (rws *ReadWriterService) Write(p []byte) (int, error) { return len(p), nil }
+
+type ReadCloserService struct{}
+
+func (rcs *ReadCloserService) Read(p []byte) (int, error) { return 0, io.EOF }
+func (rcs *ReadCloserService) Close() error               { return nil }
+
+func TestInterfaceFallback(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*ReadWriterService](sc, With[io.ReadWriter](), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[io.Reader](ctx, sc); err == nil {
+		t.Fatal("Expected exact matching without interface fallback")
+	}
+
+	sc.SetInterfaceFallback(true)
+
+	reader, err := Resolve[io.Reader](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve reader via fallback: %v", err)
+	}
+
+	if _, ok := reader.(*ReadWriterService); !ok {
+		t.Errorf("Expected read writer implementation, got %T", reader)
+	}
+}
+
+func TestInterfaceFallbackAmbiguity(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*ReadWriterService](sc, With[io.ReadWriter]()))
+	errs.Add(Register[*ReadCloserService](sc, With[io.ReadCloser]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	sc.SetInterfaceFallback(true)
+
+	_, err := Resolve[io.Reader](ctx, sc)
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("Expected ambiguity error, got %v", err)
+	}
+
+	for _, name := range []string{"io.ReadWriter", "io.ReadCloser"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected error to contain '%s', got: %v", name, err)
+		}
+	}
+}
+
+type ReaderConsumer struct {
+	Reader io.Reader `fabric:"inject"`
+}
+
+func TestInterfaceFallbackValidation(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*ReadWriterService](sc, With[io.ReadWriter]()))
+	errs.Add(Register[*ReaderConsumer](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := sc.Validate(); err == nil {
+		t.Error("Expected validation to fail without interface fallback")
+	}
+
+	sc.SetInterfaceFallback(true)
+
+	if err := sc.Validate(); err != nil {
+		t.Errorf("Expected validation to apply the interface fallback, got %v", err)
+	}
+
+	if err := CanResolve[io.Reader](ctx, sc); err != nil {
+		t.Errorf("Expected reader to be resolvable via fallback, got %v", err)
+	}
+
+	if _, err := Resolve[*ReaderConsumer](ctx, sc); err != nil {
+		t.Errorf("Failed to resolve consumer via fallback: %v", err)
+	}
+
+	if err := Register[*ReadCloserService](sc, With[io.ReadCloser]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := CanResolve[io.Reader](ctx, sc); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("Expected ambiguous fallback to be reported, got %v", err)
+	}
+}
+
+func TestInterfaceFallbackInChildContainers(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+	sc.SetInterfaceFallback(true)
+
+	if err := Register[*ReadCloserService](sc, With[io.ReadCloser](), AsScoped()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	child := sc.CreateChild()
+	if err := Register[*ReadWriterService](child, WithName[io.ReadWriter]("child")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	reader, err := ResolveName[io.Reader](ctx, child, "child")
+	if err != nil {
+		t.Fatalf("Failed to resolve reader registered in child via fallback: %v", err)
+	}
+
+	if _, ok := reader.(*ReadWriterService); !ok {
+		t.Errorf("Expected read writer implementation, got %T", reader)
+	}
+
+	scope := sc.CreateScope(ctx)
+	defer scope.Close(ctx)
+
+	if err := CanResolve[io.Reader](ctx, scope.ServiceContainer); err != nil {
+		t.Errorf("Expected reader to be resolvable in scope via fallback, got %v", err)
+	}
+
+	reader, err = Resolve[io.Reader](ctx, scope.ServiceContainer)
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped reader via fallback: %v", err)
+	}
+
+	if _, ok := reader.(*ReadCloserService); !ok {
+		t.Errorf("Expected read closer implementation, got %T", reader)
+	}
+}
@@ -0,0 +1,129 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// contextType is the reflect.Type of context.Context
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+	// errorType is the reflect.Type of error
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Invoke calls the provided function, resolving each of its parameters from the
+// container by type. Parameters of type context.Context receive the provided context.
+// The function's results are returned as reflect.Value slice. If the function's last
+// return value is a non-nil error, it is returned alongside the results.
+//
+// This is useful for running application entrypoints and one-off tasks with full
+// dependency injection.
+//
+// Example:
+//
+//	_, err := container.Invoke(ctx, func(ctx context.Context, logger Logger, db Database) error {
+//		logger.Log("Running migrations")
+//		return db.Migrate(ctx)
+//	})
+func (sc *ServiceContainer) Invoke(ctx context.Context, fn any) ([]reflect.Value, error) {
+	val := reflect.ValueOf(fn)
+	if !val.IsValid() || val.Kind() != reflect.Func || val.IsNil() {
+		return nil, fmt.Errorf("invoke target must be a non-nil function, got %T", fn)
+	}
+
+	args, err := sc.resolveArguments(ctx, val.Type(), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke '%T': %w", fn, err)
+	}
+
+	results := val.Call(args)
+	return results, lastError(results)
+}
+
+// InvokeMethod calls the method with the given name on the provided instance, resolving
+// each of its parameters from the container by type. Parameters of type context.Context
+// receive the provided context. If the method's last return value is an error, it is
+// returned by InvokeMethod.
+//
+// This complements field injection for services that prefer receiving their
+// dependencies through an init-style method.
+//
+// Example:
+//
+//	func (s *UserService) Wire(logger Logger, db Database) error {
+//		s.logger, s.db = logger, db
+//		return nil
+//	}
+//
+//	err := container.InvokeMethod(ctx, userService, "Wire")
+func (sc *ServiceContainer) InvokeMethod(ctx context.Context, instance any, method string) error {
+	val := reflect.ValueOf(instance)
+	if !val.IsValid() {
+		return fmt.Errorf("can not invoke method '%s' on nil instance", method)
+	}
+
+	fn := val.MethodByName(method)
+	if !fn.IsValid() {
+		return fmt.Errorf("method '%s' not found on '%T'", method, instance)
+	}
+
+	args, err := sc.resolveArguments(ctx, fn.Type(), 0)
+	if err != nil {
+		return fmt.Errorf("failed to invoke method '%s' on '%T': %w", method, instance, err)
+	}
+
+	return lastError(fn.Call(args))
+}
+
+// resolveArguments resolves a value for every parameter of the provided function type,
+// starting at the provided offset. Parameters before the offset are left for the caller.
+// Parameters of type context.Context receive the provided context, all other parameters
+// are resolved from the container by their type using the empty name.
+func (sc *ServiceContainer) resolveArguments(ctx context.Context, fnType reflect.Type, offset int) ([]reflect.Value, error) {
+	if fnType.IsVariadic() {
+		return nil, fmt.Errorf("variadic functions are not supported")
+	}
+
+	args := make([]reflect.Value, fnType.NumIn())
+	for i := offset; i < fnType.NumIn(); i++ {
+		paramType := fnType.In(i)
+
+		if paramType == contextType {
+			args[i] = reflect.ValueOf(&ctx).Elem()
+			continue
+		}
+
+		resolved, err := sc.resolve(ctx, paramType, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve parameter %d of type '%s': %w", i, paramType, err)
+		}
+
+		arg := reflect.ValueOf(resolved)
+		if !arg.Type().AssignableTo(paramType) {
+			return nil, fmt.Errorf("resolved '%s' is not assignable to parameter %d of type '%s'", arg.Type(), i, paramType)
+		}
+
+		args[i] = reflect.New(paramType).Elem()
+		args[i].Set(arg)
+	}
+
+	return args, nil
+}
+
+// lastError returns the last result as error if it implements the error interface
+// and is not nil.
+func lastError(results []reflect.Value) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	last := results[len(results)-1]
+	if last.Type() != errorType || last.IsNil() {
+		return nil
+	}
+
+	return last.Interface().(error)
+}
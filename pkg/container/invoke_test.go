@@ -0,0 +1,89 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type WiredService struct {
+	ctx     context.Context
+	logger  LoggerEngine
+	encrypt *EncryptService
+}
+
+func (ws *WiredService) Wire(ctx context.Context, logger LoggerEngine, encrypt *EncryptService) error {
+	ws.ctx, ws.logger, ws.encrypt = ctx, logger, encrypt
+	return nil
+}
+
+func (ws *WiredService) Fail(logger LoggerEngine) error {
+	return errors.New("wiring failed")
+}
+
+func (ws *WiredService) Missing(cache *CacheService) {}
+
+func TestInvokeMethod(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	service := &WiredService{}
+	if err := sc.InvokeMethod(ctx, service, "Wire"); err != nil {
+		t.Fatalf("Failed to invoke method: %v", err)
+	}
+
+	if service.ctx == nil || service.logger == nil || service.encrypt == nil {
+		t.Error("Expected all method parameters to be resolved")
+	}
+
+	if err := sc.InvokeMethod(ctx, service, "Fail"); err == nil {
+		t.Error("Expected error returned by method to be surfaced")
+	}
+
+	if err := sc.InvokeMethod(ctx, service, "Missing"); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected ErrNotRegistered for unresolved parameter, got %v", err)
+	}
+
+	if err := sc.InvokeMethod(ctx, service, "Unknown"); err == nil {
+		t.Error("Expected unknown method to fail")
+	}
+}
+
+func TestInvokeFunction(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	results, err := sc.Invoke(ctx, func(ctx context.Context, logger LoggerEngine) (string, error) {
+		if logger == nil {
+			return "", errors.New("logger not resolved")
+		}
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to invoke function: %v", err)
+	}
+
+	if len(results) != 2 || results[0].String() != "done" {
+		t.Errorf("Expected function results to be returned, got %v", results)
+	}
+
+	if _, err := sc.Invoke(ctx, func(cache *CacheService) {}); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected ErrNotRegistered for unresolved parameter, got %v", err)
+	}
+
+	if _, err := sc.Invoke(ctx, "not a function"); err == nil {
+		t.Error("Expected invoking non-function to fail")
+	}
+}
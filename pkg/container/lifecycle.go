@@ -1,6 +1,11 @@
 package container
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
 
 // LifecycleService is an interface for services that require initialization
 // and cleanup during their lifecycle. Services implementing this interface
@@ -9,7 +14,8 @@ import "context"
 //
 // The Init method is called immediately after a service is created and before
 // it is returned to the caller. The Cleanup method is called in reverse order
-// of registration during container cleanup.
+// of registration during container cleanup, unless the registration provides a
+// hint via AsCleanupFirst or AsCleanupLast.
 //
 // Example:
 //
@@ -37,16 +43,198 @@ type LifecycleService interface {
 	Cleanup(context.Context) error
 }
 
-// runLifecycle checks if the provided service implements LifecycleService and,
-// if so, calls its Init method and registers it for cleanup during container shutdown.
-// This method is called internally during service resolution.
-func (sc *ServiceContainer) runLifecycle(ctx context.Context, singleton any) error {
-	if lifecycle, ok := singleton.(LifecycleService); ok {
-		if err := lifecycle.Init(ctx); err != nil {
-			return err
+// lifecycleEntry stores an initialized lifecycle service with the cleanup ordering
+// hints of the registration it was created from.
+type lifecycleEntry struct {
+	service LifecycleService
+
+	// first and last move the cleanup before or after all other services
+	first bool
+	last  bool
+}
+
+// initLifecycle checks if the provided instance implements LifecycleService and, if so,
+// calls its Init method. It is called during service resolution without holding the lock, so
+// a slow Init only blocks resolutions waiting for the same instance. Instances that have
+// already been initialized (e.g. a pre-created instance reached through multiple resolution
+// paths) are skipped, so Init is only called once, and concurrent resolutions of the same
+// instance wait for the running initialization.
+//
+// The returned lifecycle is nil for skipped instances. Otherwise, it must be passed to
+// trackLifecycle to register it for cleanup and release it to waiting resolutions.
+func (sc *ServiceContainer) initLifecycle(ctx context.Context, instance any, service *RegistrationService) (LifecycleService, error) {
+	lifecycle, ok := instance.(LifecycleService)
+	if !ok {
+		return nil, nil
+	}
+
+	if session, inSession := sessionFromContext(ctx); inSession && session.isPending(lifecycle) {
+		return nil, nil
+	}
+
+	claimed, err := sc.claimInit(ctx, lifecycle)
+	if err != nil || !claimed {
+		return nil, err
+	}
+
+	initCtx, cancel := service.constructContext(ctx)
+	initErr := lifecycle.Init(initCtx)
+	err = service.checkConstructTimeout(initCtx, "Init", initErr)
+	cancel()
+	if err != nil {
+		sc.mu.Lock()
+		sc.releaseInit(lifecycle)
+		sc.mu.Unlock()
+
+		// Instances initialized after the timeout are not owned by anyone, so they are cleaned up immediately
+		if initErr == nil {
+			if cleanupErr := lifecycle.Cleanup(ctx); cleanupErr != nil {
+				return nil, errors.Join(err, fmt.Errorf("failed to cleanup '%T' after construction timeout: %w", lifecycle, cleanupErr))
+			}
+		}
+		return nil, err
+	}
+
+	return lifecycle, nil
+}
+
+// trackLifecycle registers a lifecycle instance initialized via initLifecycle for cleanup
+// during container shutdown and wakes up resolutions waiting for its initialization. The
+// caller must hold the lock.
+//
+// Cached instances are registered for cleanup immediately, since the container owns them
+// from now on. All other instances are only registered once the resolution session has
+// succeeded (see withSession), so instances of failed resolutions never enter the cleanup list.
+func (sc *ServiceContainer) trackLifecycle(ctx context.Context, lifecycle LifecycleService, service *RegistrationService, cached bool) {
+	if lifecycle == nil {
+		return
+	}
+	defer sc.releaseInit(lifecycle)
+
+	// Explicitly transient instances are owned by the caller and not cleaned up by the container
+	if service.IsTransient && !service.IsSingleton && !service.IsScoped {
+		return
+	}
+
+	entry := lifecycleEntry{
+		service: lifecycle,
+		first:   service.CleanupFirst,
+		last:    service.CleanupLast,
+	}
+
+	if session, inSession := sessionFromContext(ctx); !cached && inSession {
+		session.deferLifecycle(sc, entry)
+		return
+	}
+
+	sc.addLifecycle(entry)
+}
+
+// claimInit waits until no other resolution is initializing the provided instance and
+// reports whether the caller has to initialize it, which is not the case once it has been
+// initialized in the meantime. Claimed instances are released via releaseInit. Only
+// comparable instances can be claimed; all other instances are always initialized.
+func (sc *ServiceContainer) claimInit(ctx context.Context, lifecycle LifecycleService) (bool, error) {
+	if !reflect.TypeOf(lifecycle).Comparable() {
+		return true, nil
+	}
+
+	for {
+		sc.mu.Lock()
+		if sc.isInitialized(lifecycle) {
+			sc.mu.Unlock()
+			return false, nil
 		}
-		sc.lifecycles = append(sc.lifecycles, lifecycle)
+
+		running, exists := sc.initializing[lifecycle]
+		if !exists {
+			sc.initializing[lifecycle] = make(chan struct{})
+			sc.mu.Unlock()
+			return true, nil
+		}
+		sc.mu.Unlock()
+
+		select {
+		case <-running:
+		case <-ctx.Done():
+			return false, fmt.Errorf("failed to wait for initialization of '%T': %w", lifecycle, ctx.Err())
+		}
+	}
+}
+
+// releaseInit releases the claim of the provided instance acquired via claimInit. The
+// caller must hold the lock.
+func (sc *ServiceContainer) releaseInit(lifecycle LifecycleService) {
+	if lifecycle == nil || !reflect.TypeOf(lifecycle).Comparable() {
+		return
+	}
+
+	if running, exists := sc.initializing[lifecycle]; exists {
+		delete(sc.initializing, lifecycle)
+		close(running)
+	}
+}
+
+// addLifecycle registers an initialized lifecycle instance for cleanup. The caller must
+// hold the lock.
+func (sc *ServiceContainer) addLifecycle(entry lifecycleEntry) {
+	sc.lifecycles = append(sc.lifecycles, entry)
+	if reflect.TypeOf(entry.service).Comparable() {
+		sc.initialized[entry.service] = struct{}{}
+	}
+}
+
+// cleanupOrder returns the provided lifecycle services in cleanup order: services
+// hinted via AsCleanupFirst, then all other services, then services hinted via
+// AsCleanupLast. Within each group, services are cleaned up in reverse order.
+func cleanupOrder(entries []lifecycleEntry) []LifecycleService {
+	first := make([]LifecycleService, 0)
+	middle := make([]LifecycleService, 0)
+	last := make([]LifecycleService, 0)
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		switch entry := entries[i]; {
+		case entry.first:
+			first = append(first, entry.service)
+		case entry.last:
+			last = append(last, entry.service)
+		default:
+			middle = append(middle, entry.service)
+		}
+	}
+
+	return append(append(first, middle...), last...)
+}
+
+// isManaged reports whether the provided instance has been registered for cleanup by
+// this container or one of its parents.
+func (sc *ServiceContainer) isManaged(instance any) bool {
+	lifecycle, ok := instance.(LifecycleService)
+	if !ok {
+		return false
+	}
+
+	for current := sc; current != nil; current = current.parent {
+		current.mu.RLock()
+		initialized := current.isInitialized(lifecycle)
+		current.mu.RUnlock()
+
+		if initialized {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isInitialized reports whether the provided lifecycle instance has already been
+// initialized and registered for cleanup. Only comparable instances (such as
+// pointers) can be tracked; all other instances are treated as not initialized.
+func (sc *ServiceContainer) isInitialized(lifecycle LifecycleService) bool {
+	if !reflect.TypeOf(lifecycle).Comparable() {
+		return false
 	}
 
-	return nil
+	_, initialized := sc.initialized[lifecycle]
+	return initialized
 }
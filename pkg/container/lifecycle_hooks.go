@@ -0,0 +1,286 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// DefaultShutdownTimeout is the duration RunUntilSignal grants the shutdown of a container,
+// unless overridden via SetShutdownTimeout.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Hook is a set of lifecycle callbacks registered via Lifecycle.Append.
+// All callbacks are optional.
+type Hook struct {
+	// OnStart is called during StartAll in the order hooks were appended
+	OnStart func(context.Context) error
+
+	// OnRun is a long-running callback called concurrently with all other OnRun callbacks
+	// during StartAll, once all OnStart callbacks have succeeded. It should block until
+	// its context is cancelled or its work is done
+	OnRun func(context.Context) error
+
+	// OnStop is called during Cleanup in reverse order of appending
+	OnStop func(context.Context) error
+}
+
+// Lifecycle collects hooks registered by services, decoupling lifecycle management
+// from the LifecycleService interface and allowing a single constructor to register
+// multiple hooks. Every container provides its own *Lifecycle, which can be resolved
+// or injected like any other service.
+//
+// Example:
+//
+//	Register[*HttpServer](container, AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+//		lc, err := Resolve[*Lifecycle](ctx, sc)
+//		if err != nil {
+//			return nil, err
+//		}
+//		server := &http.Server{Addr: ":8080"}
+//		lc.Append(Hook{
+//			OnStart: func(ctx context.Context) error { go server.ListenAndServe(); return nil },
+//			OnStop:  func(ctx context.Context) error { return server.Shutdown(ctx) },
+//		})
+//		return &HttpServer{server: server}, nil
+//	}))
+type Lifecycle struct {
+	mu    sync.Mutex
+	hooks []*lifecycleHook
+
+	// starting serializes concurrent starts, so hooks are started once and in order
+	starting sync.Mutex
+}
+
+// lifecycleHook tracks an appended hook together with its start state.
+type lifecycleHook struct {
+	hook    Hook
+	started bool
+	running bool
+}
+
+// newLifecycle creates a new Lifecycle without any hooks.
+func newLifecycle() *Lifecycle {
+	return &Lifecycle{
+		hooks: make([]*lifecycleHook, 0),
+	}
+}
+
+// Append registers a hook with the lifecycle.
+func (lc *Lifecycle) Append(hook Hook) {
+	lc.mu.Lock()
+	lc.hooks = append(lc.hooks, &lifecycleHook{hook: hook})
+	lc.mu.Unlock()
+}
+
+// start calls OnStart for all hooks that have not been started yet, in the order they
+// were appended. It stops at and returns the first error. The start state of the hooks
+// is only accessed under the lock, while the callbacks are called without holding it.
+func (lc *Lifecycle) start(ctx context.Context) error {
+	lc.starting.Lock()
+	defer lc.starting.Unlock()
+
+	lc.mu.Lock()
+	hooks := append([]*lifecycleHook{}, lc.hooks...)
+	lc.mu.Unlock()
+
+	for i, h := range hooks {
+		lc.mu.Lock()
+		started := h.started
+		lc.mu.Unlock()
+
+		if started {
+			continue
+		}
+
+		if h.hook.OnStart != nil {
+			if err := h.hook.OnStart(ctx); err != nil {
+				return fmt.Errorf("failed to start hook %d: %w", i, err)
+			}
+		}
+
+		lc.mu.Lock()
+		h.started = true
+		lc.mu.Unlock()
+	}
+
+	return nil
+}
+
+// run calls OnRun concurrently for all started hooks that are not running yet and
+// waits for all of them to return. Similar to an errgroup, the first non-nil error
+// cancels the context passed to all other OnRun callbacks and is returned once all
+// callbacks have returned. Callbacks returning nil, such as one-shot tasks, do not
+// affect the others.
+func (lc *Lifecycle) run(ctx context.Context) error {
+	type runner struct {
+		index int
+		run   func(context.Context) error
+	}
+
+	lc.mu.Lock()
+	runners := make([]runner, 0)
+	for i, h := range lc.hooks {
+		if h.started && !h.running && h.hook.OnRun != nil {
+			h.running = true
+			runners = append(runners, runner{index: i, run: h.hook.OnRun})
+		}
+	}
+	lc.mu.Unlock()
+
+	if len(runners) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var first error
+
+	for _, r := range runners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := r.run(ctx); err != nil {
+				once.Do(func() {
+					first = fmt.Errorf("failed to run hook %d: %w", r.index, err)
+					cancel(first)
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+	return first
+}
+
+// stop calls OnStop in reverse order of appending for all hooks that have been started
+// or have no OnStart callback, and removes all hooks from the lifecycle.
+func (lc *Lifecycle) stop(ctx context.Context) error {
+	lc.mu.Lock()
+	hooks := lc.hooks
+	lc.hooks = make([]*lifecycleHook, 0)
+
+	stoppable := make([]bool, len(hooks))
+	for i, h := range hooks {
+		stoppable[i] = h.hook.OnStop != nil && (h.hook.OnStart == nil || h.started)
+	}
+	lc.mu.Unlock()
+
+	errs := &Errors{}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if !stoppable[i] {
+			continue
+		}
+
+		if err := hooks[i].hook.OnStop(ctx); err != nil {
+			errs.Add(fmt.Errorf("failed to stop hook %d: %w", i, err))
+		}
+	}
+
+	return errs.Errors()
+}
+
+// StartAll calls the OnStart callback of every hook appended to the container's
+// Lifecycle, in the order they were appended. Hooks that have already been started
+// are skipped, so StartAll can be called again after further services were resolved.
+// The first failing hook aborts the start and its error is returned.
+//
+// Once all hooks have been started, the OnRun callbacks of all hooks are called
+// concurrently and StartAll blocks until every one of them has returned, turning the
+// container into an application runner. The first OnRun callback returning a non-nil
+// error cancels the context of all others and its error is returned by StartAll.
+// Callbacks returning nil do not count as failures. If no hook has an OnRun callback,
+// StartAll returns as soon as all hooks have been started.
+//
+// Example:
+//
+//	if err := container.StartAll(ctx); err != nil {
+//		log.Fatalf("Failed to run application: %v", err)
+//	}
+func (sc *ServiceContainer) StartAll(ctx context.Context) error {
+	if err := sc.lifecycle.start(ctx); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	if err := sc.lifecycle.run(ctx); err != nil {
+		return fmt.Errorf("failed to run container: %w", err)
+	}
+
+	return nil
+}
+
+// RunUntilSignal runs the container as a complete application: it starts all hooks via
+// StartAll, blocks until one of the provided signals arrives or the context is cancelled,
+// and then shuts the container down via Cleanup, calling the OnStop callbacks of all hooks
+// and cleaning up all lifecycle services. If no signals are provided, os.Interrupt is used.
+//
+// The context passed to OnStart and OnRun callbacks is cancelled once a signal arrives, so
+// long-running OnRun callbacks can return. If StartAll fails, the shutdown starts
+// immediately. The shutdown receives a context detached from the cancellation of ctx with a
+// deadline of DefaultShutdownTimeout, which can be overridden via SetShutdownTimeout;
+// services are expected to respect it. Errors of the start and the shutdown are returned
+// as a single aggregated error.
+//
+// Example:
+//
+//	func main() {
+//		container := NewServiceContainer()
+//		// Register services...
+//
+//		if err := container.RunUntilSignal(context.Background(), os.Interrupt, syscall.SIGTERM); err != nil {
+//			log.Fatalf("Failed to run application: %v", err)
+//		}
+//	}
+func (sc *ServiceContainer) RunUntilSignal(ctx context.Context, signals ...os.Signal) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt}
+	}
+
+	runCtx, stop := signal.NotifyContext(ctx, signals...)
+	defer stop()
+
+	errs := &Errors{}
+	if err := sc.StartAll(runCtx); err != nil {
+		errs.Add(err)
+	} else {
+		<-runCtx.Done()
+	}
+	stop()
+
+	sc.mu.RLock()
+	timeout := sc.shutdownTimeout
+	sc.mu.RUnlock()
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	if err := sc.Cleanup(shutdownCtx); err != nil {
+		errs.Add(fmt.Errorf("failed to shut down container: %w", err))
+	}
+
+	return errs.Errors()
+}
+
+// SetShutdownTimeout sets the duration RunUntilSignal grants the shutdown of the container,
+// replacing DefaultShutdownTimeout. A timeout of zero or less restores the default.
+//
+// Example:
+//
+//	container.SetShutdownTimeout(10 * time.Second)
+func (sc *ServiceContainer) SetShutdownTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.shutdownTimeout = timeout
+}
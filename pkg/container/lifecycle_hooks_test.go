@@ -0,0 +1,353 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type HookedService struct {
+	Lifecycle *Lifecycle `fabric:"inject"`
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*HookedService](sc, AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	service, err := Resolve[*HookedService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+
+	order := make([]string, 0)
+	for _, name := range []string{"first", "second"} {
+		service.Lifecycle.Append(Hook{
+			OnStart: func(ctx context.Context) error {
+				order = append(order, "start "+name)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				order = append(order, "stop "+name)
+				return nil
+			},
+		})
+	}
+
+	if err := sc.StartAll(ctx); err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+
+	if err := sc.Cleanup(ctx); err != nil {
+		t.Fatalf("Failed to cleanup container: %v", err)
+	}
+
+	expected := []string{"start first", "start second", "stop second", "stop first"}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected hooks %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("Expected hooks %v, got %v", expected, order)
+			break
+		}
+	}
+}
+
+func TestStartAllRunsUntilFirstError(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	lc, err := Resolve[*Lifecycle](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve lifecycle: %v", err)
+	}
+
+	failure := errors.New("server crashed")
+	cancelled := make(chan struct{})
+	oneShot := false
+
+	lc.Append(Hook{
+		OnRun: func(ctx context.Context) error {
+			oneShot = true
+			return nil
+		},
+	})
+	lc.Append(Hook{
+		OnRun: func(ctx context.Context) error {
+			<-ctx.Done()
+			close(cancelled)
+			return ctx.Err()
+		},
+	})
+	lc.Append(Hook{
+		OnRun: func(ctx context.Context) error {
+			return failure
+		},
+	})
+
+	if err := sc.StartAll(ctx); !errors.Is(err, failure) {
+		t.Errorf("Expected StartAll to return the first failure, got %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	default:
+		t.Error("Expected long-running hook to be cancelled")
+	}
+
+	if !oneShot {
+		t.Error("Expected one-shot hook to have run")
+	}
+}
+
+func TestStartAllWithoutRunners(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	lc, err := Resolve[*Lifecycle](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve lifecycle: %v", err)
+	}
+
+	lc.Append(Hook{
+		OnRun: func(ctx context.Context) error {
+			return nil
+		},
+	})
+
+	if err := sc.StartAll(ctx); err != nil {
+		t.Errorf("Expected hooks returning nil not to fail, got %v", err)
+	}
+}
+
+func TestRunUntilSignal(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*CounterService](sc, AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	counter, err := Resolve[*CounterService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	lc, err := Resolve[*Lifecycle](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve lifecycle: %v", err)
+	}
+
+	var deadline time.Time
+	lc.Append(Hook{
+		OnStart: func(ctx context.Context) error {
+			// Raise the signal once RunUntilSignal is listening for it
+			process, err := os.FindProcess(os.Getpid())
+			if err != nil {
+				return err
+			}
+			return process.Signal(os.Interrupt)
+		},
+		OnRun: func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			deadline, _ = ctx.Deadline()
+			return ctx.Err()
+		},
+	})
+
+	sc.SetShutdownTimeout(time.Minute)
+	if err := sc.RunUntilSignal(ctx, os.Interrupt); err != nil {
+		t.Fatalf("Expected run to shut down cleanly, got %v", err)
+	}
+
+	if counter.cleanups != 1 {
+		t.Errorf("Expected lifecycle services to be cleaned up once, got %d", counter.cleanups)
+	}
+
+	if remaining := time.Until(deadline); remaining <= DefaultShutdownTimeout || remaining > time.Minute {
+		t.Errorf("Expected shutdown to receive the configured timeout, got %s", remaining)
+	}
+}
+
+func TestRunUntilSignalStartFailure(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	lc, err := Resolve[*Lifecycle](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve lifecycle: %v", err)
+	}
+
+	failure := errors.New("port in use")
+	stopped := false
+	lc.Append(Hook{
+		OnStop: func(ctx context.Context) error {
+			stopped = true
+			return nil
+		},
+	})
+	lc.Append(Hook{
+		OnStart: func(ctx context.Context) error {
+			return failure
+		},
+	})
+
+	// The context is never cancelled, so the run must not wait for a signal after a failed start
+	if err := sc.RunUntilSignal(ctx); !errors.Is(err, failure) {
+		t.Errorf("Expected start failure to be returned, got %v", err)
+	}
+
+	if !stopped {
+		t.Error("Expected container to be shut down after failed start")
+	}
+}
+
+func TestRunUntilSignalContextCancelled(t *testing.T) {
+	sc := NewServiceContainer()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if err := Register[*CounterService](sc, AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	counter, err := Resolve[*CounterService](t.Context(), sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	if err := sc.RunUntilSignal(ctx); err != nil {
+		t.Fatalf("Expected cancelled run to shut down cleanly, got %v", err)
+	}
+
+	if counter.cleanups != 1 {
+		t.Errorf("Expected lifecycle services to be cleaned up on cancellation, got %d", counter.cleanups)
+	}
+}
+
+func TestConcurrentStartAllStartsHooksOnce(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	lc, err := Resolve[*Lifecycle](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve lifecycle: %v", err)
+	}
+
+	const hooks = 20
+	starts := make([]atomic.Int32, hooks)
+	appendHook := func(i int) {
+		lc.Append(Hook{
+			OnStart: func(ctx context.Context) error {
+				starts[i].Add(1)
+				time.Sleep(time.Millisecond)
+				return nil
+			},
+		})
+	}
+
+	for i := range hooks / 2 {
+		appendHook(i)
+	}
+
+	// Start concurrently while the remaining hooks are appended
+	ready := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ready
+		for i := hooks / 2; i < hooks; i++ {
+			appendHook(i)
+		}
+	}()
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ready
+			if err := sc.StartAll(ctx); err != nil {
+				t.Errorf("Failed to start container: %v", err)
+			}
+		}()
+	}
+	close(ready)
+	wg.Wait()
+
+	if err := sc.StartAll(ctx); err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+
+	for i := range starts {
+		if count := starts[i].Load(); count != 1 {
+			t.Errorf("Expected hook %d to be started once, got %d", i, count)
+		}
+	}
+}
+
+func TestConcurrentStartAllRunsHooksOnce(t *testing.T) {
+	sc := NewServiceContainer()
+
+	lc, err := Resolve[*Lifecycle](t.Context(), sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve lifecycle: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var runs atomic.Int32
+	running := make(chan struct{}, 8)
+	runner := Hook{
+		OnRun: func(ctx context.Context) error {
+			runs.Add(1)
+			running <- struct{}{}
+			<-ctx.Done()
+			return nil
+		},
+	}
+	lc.Append(runner)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sc.StartAll(ctx); err != nil {
+				t.Errorf("Failed to run container: %v", err)
+			}
+		}()
+	}
+
+	// Append another runner while the first one is running, started by a further StartAll
+	<-running
+	lc.Append(runner)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := sc.StartAll(ctx); err != nil {
+			t.Errorf("Failed to run container: %v", err)
+		}
+	}()
+	<-running
+
+	cancel()
+	wg.Wait()
+
+	if count := runs.Load(); count != 2 {
+		t.Errorf("Expected each runner to run once, got %d runs", count)
+	}
+}
@@ -0,0 +1,714 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type CounterEngine interface {
+	Count() int
+}
+
+type CounterService struct {
+	inits    int
+	cleanups int
+}
+
+func (cs *CounterService) Init(ctx context.Context) error {
+	cs.inits++
+	return nil
+}
+
+func (cs *CounterService) Cleanup(ctx context.Context) error {
+	cs.cleanups++
+	return nil
+}
+
+func (cs *CounterService) Count() int {
+	return cs.inits
+}
+
+type CounterAgent struct {
+	Counter *CounterService `fabric:"inject"`
+}
+
+func TestWithInstanceLifecycleInitOnce(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	counter := &CounterService{}
+	if err := Register[*CounterService](sc,
+		WithInstance(counter),
+		With[CounterEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := Resolve[*CounterService](ctx, sc); err != nil {
+			t.Fatalf("Failed to resolve counter: %v", err)
+		}
+		if _, err := Resolve[CounterEngine](ctx, sc); err != nil {
+			t.Fatalf("Failed to resolve counter engine: %v", err)
+		}
+	}
+
+	if counter.inits != 1 {
+		t.Errorf("Expected Init to be called once, got %d", counter.inits)
+	}
+
+	if err := sc.Cleanup(ctx); err != nil {
+		t.Fatalf("Failed to cleanup container: %v", err)
+	}
+
+	if counter.cleanups != 1 {
+		t.Errorf("Expected Cleanup to be called once, got %d", counter.cleanups)
+	}
+}
+
+func TestWithInstanceKeepsLifetimeOptions(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	scoped := &CounterService{}
+	transient := &NamedLogger{name: "transient"}
+
+	errs := &Errors{}
+	errs.Add(Register[*CounterService](sc, WithInstance(scoped), AsScoped()))
+	errs.Add(Register[*NamedLogger](sc, AsTransient(), WithInstance(transient)))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*CounterService](ctx, sc); err == nil {
+		t.Error("Expected scoped instance registration to require a scope")
+	}
+
+	scope := sc.CreateScope(ctx)
+	if counter, err := Resolve[*CounterService](ctx, scope.ServiceContainer); err != nil || counter != scoped {
+		t.Fatalf("Failed to resolve scoped instance: %v", err)
+	}
+
+	// Pre-created instances do not turn their registration into a singleton, regardless of the option order
+	if info, ok := Lookup[*CounterService](sc, ""); !ok || info.IsSingleton {
+		t.Errorf("Expected scoped instance registration not to be a singleton, got %+v", info)
+	}
+
+	if info, ok := Lookup[*NamedLogger](sc, ""); !ok || info.IsSingleton {
+		t.Errorf("Expected transient instance registration not to be a singleton, got %+v", info)
+	}
+
+	if err := scope.Close(ctx); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+
+	if scoped.inits != 1 || scoped.cleanups != 1 {
+		t.Errorf("Expected scoped instance to be initialized and cleaned up once, got %d and %d", scoped.inits, scoped.cleanups)
+	}
+}
+
+func TestResolveByTypeLifecycleInit(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	counter := &CounterService{}
+	if err := Register[*CounterService](sc, WithInstance(counter)); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	ok, resolved := sc.ResolveByType(ctx, typeKey[*CounterService]())
+	if !ok {
+		t.Fatal("Failed to resolve counter by type")
+	}
+
+	if resolved != counter {
+		t.Error("Resolved instance does not match registered instance")
+	}
+
+	if ok, _ := sc.ResolveByType(ctx, typeKey[*CounterService]()); !ok {
+		t.Fatal("Failed to resolve counter by type")
+	}
+
+	if _, err := Resolve[*CounterService](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	if counter.inits != 1 {
+		t.Errorf("Expected Init to be called once, got %d", counter.inits)
+	}
+}
+
+func TestTagInjectionWithInstanceLifecycleInit(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	counter := &CounterService{}
+
+	errs := &Errors{}
+	errs.Add(Register[*CounterAgent](sc))
+	errs.Add(Register[*CounterService](sc, WithInstance(counter)))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		agent, err := Resolve[*CounterAgent](ctx, sc)
+		if err != nil {
+			t.Fatalf("Failed to resolve agent: %v", err)
+		}
+		if agent.Counter != counter {
+			t.Error("Counter was not successfully injected")
+		}
+	}
+
+	if counter.inits != 1 {
+		t.Errorf("Expected Init to be called once, got %d", counter.inits)
+	}
+}
+
+func TestTagInjectionLifecycleInit(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*CounterAgent](sc))
+	errs.Add(Register[*CounterService](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	agent, err := Resolve[*CounterAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Counter == nil {
+		t.Fatal("Counter was not successfully injected")
+	}
+
+	if agent.Counter.inits != 1 {
+		t.Errorf("Expected Init to be called once, got %d", agent.Counter.inits)
+	}
+}
+
+type FailingService struct {
+	attempts *int
+}
+
+func (fs *FailingService) Init(ctx context.Context) error {
+	*fs.attempts++
+	return errors.New("init failed")
+}
+
+func (fs *FailingService) Cleanup(ctx context.Context) error {
+	return nil
+}
+
+func TestSingletonFailedInitRetry(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	attempts := 0
+	if err := Register[*FailingService](sc,
+		AsSingleton(),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			return &FailingService{attempts: &attempts}, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := Resolve[*FailingService](ctx, sc); err == nil {
+			t.Fatal("Expected resolution to fail")
+		}
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected Init to be retried 3 times, got %d", attempts)
+	}
+}
+
+func TestSingletonFailedInitCached(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	attempts := 0
+	if err := Register[*FailingService](sc,
+		AsSingleton(),
+		CacheFailedInit(true),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			return &FailingService{attempts: &attempts}, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	_, first := Resolve[*FailingService](ctx, sc)
+	if first == nil {
+		t.Fatal("Expected resolution to fail")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := Resolve[*FailingService](ctx, sc); err != first {
+			t.Errorf("Expected cached error %v, got %v", first, err)
+		}
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected Init to be called once, got %d", attempts)
+	}
+}
+
+func TestCleanupIdempotent(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*CounterService](sc, AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	counter, err := Resolve[*CounterService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := sc.Cleanup(ctx); err != nil {
+			t.Fatalf("Failed to cleanup container: %v", err)
+		}
+	}
+
+	if counter.cleanups != 1 {
+		t.Errorf("Expected Cleanup to be called once, got %d", counter.cleanups)
+	}
+}
+
+type OrderedCleanupService struct {
+	name  string
+	order *[]string
+}
+
+func (ocs *OrderedCleanupService) Init(ctx context.Context) error {
+	return nil
+}
+
+func (ocs *OrderedCleanupService) Cleanup(ctx context.Context) error {
+	*ocs.order = append(*ocs.order, ocs.name)
+	return nil
+}
+
+func TestCleanupOrderingHints(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	order := make([]string, 0)
+	factory := AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return &OrderedCleanupService{name: NameFromContext(ctx), order: &order}, nil
+	})
+
+	errs := &Errors{}
+	errs.Add(Register[*OrderedCleanupService](sc, AsNamed("logger"), AsSingleton(), AsCleanupLast(), factory))
+	errs.Add(Register[*OrderedCleanupService](sc, AsNamed("database"), AsSingleton(), factory))
+	errs.Add(Register[*OrderedCleanupService](sc, AsNamed("cache"), AsSingleton(), factory))
+	errs.Add(Register[*OrderedCleanupService](sc, AsNamed("server"), AsSingleton(), AsCleanupFirst(), factory))
+	errs.Add(Register[*OrderedCleanupService](sc, AsNamed("metrics"), AsSingleton(), AsCleanupLast(), factory))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for _, name := range []string{"logger", "server", "database", "metrics", "cache"} {
+		if _, err := ResolveName[*OrderedCleanupService](ctx, sc, name); err != nil {
+			t.Fatalf("Failed to resolve '%s': %v", name, err)
+		}
+	}
+
+	if err := sc.Cleanup(ctx); err != nil {
+		t.Fatalf("Failed to cleanup container: %v", err)
+	}
+
+	expected := []string{"server", "cache", "database", "metrics", "logger"}
+	if !slices.Equal(order, expected) {
+		t.Errorf("Expected cleanup order %v, got %v", expected, order)
+	}
+}
+
+func TestTransientOwnership(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*CounterService](sc, AsTransient()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	counter, release, err := ResolveWithRelease[*CounterService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	if counter.inits != 1 {
+		t.Errorf("Expected Init to be called once, got %d", counter.inits)
+	}
+
+	if err := sc.Cleanup(ctx); err != nil {
+		t.Fatalf("Failed to cleanup container: %v", err)
+	}
+
+	if counter.cleanups != 0 {
+		t.Errorf("Expected transient not to be cleaned up by the container, got %d cleanups", counter.cleanups)
+	}
+
+	if err := release(ctx); err != nil {
+		t.Fatalf("Failed to release counter: %v", err)
+	}
+
+	if counter.cleanups != 1 {
+		t.Errorf("Expected Cleanup to be called once on release, got %d", counter.cleanups)
+	}
+}
+
+func TestReleaseContainerManagedInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*CounterService](sc, AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	counter, release, err := ResolveWithRelease[*CounterService](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	if err := release(ctx); err != nil {
+		t.Fatalf("Failed to release counter: %v", err)
+	}
+
+	if counter.cleanups != 0 {
+		t.Errorf("Expected release of singleton to be a no-op, got %d cleanups", counter.cleanups)
+	}
+
+	if err := sc.Cleanup(ctx); err != nil {
+		t.Fatalf("Failed to cleanup container: %v", err)
+	}
+
+	if counter.cleanups != 1 {
+		t.Errorf("Expected Cleanup to be called once by the container, got %d", counter.cleanups)
+	}
+}
+
+func TestFailedResolutionAfterInitNotCleanedUpTwice(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	counter := &CounterService{}
+	err := Register[*CounterAgent](sc, AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return counter, nil
+	}))
+	if err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*CounterAgent](ctx, sc); err == nil {
+		t.Fatal("Expected resolution of mismatching type to fail")
+	}
+
+	if counter.inits != 1 || counter.cleanups != 1 {
+		t.Errorf("Expected instance of failed resolution to be cleaned up immediately, got %d inits and %d cleanups", counter.inits, counter.cleanups)
+	}
+
+	if err := sc.Cleanup(ctx); err != nil {
+		t.Fatalf("Failed to cleanup container: %v", err)
+	}
+
+	if counter.cleanups != 1 {
+		t.Errorf("Expected instance of failed resolution not to enter the cleanup list, got %d cleanups", counter.cleanups)
+	}
+}
+
+type LateFailingAgent struct {
+	Counter *CounterService `fabric:"inject"`
+	Logger  LoggerEngine    `fabric:"inject,late"`
+}
+
+func TestFailedLateInjectionCleansUpInitializedDependencies(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	counter := &CounterService{}
+	errs := &Errors{}
+	errs.Add(Register[*CounterService](sc, AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return counter, nil
+	})))
+	errs.Add(Register[*LateFailingAgent](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*LateFailingAgent](ctx, sc); !errors.Is(err, ErrNotRegistered) {
+		t.Fatalf("Expected late injection to fail with ErrNotRegistered, got %v", err)
+	}
+
+	if counter.inits != 1 || counter.cleanups != 1 {
+		t.Errorf("Expected dependency of failed resolution to be cleaned up, got %d inits and %d cleanups", counter.inits, counter.cleanups)
+	}
+
+	if _, err := Resolve[*CounterService](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	if err := sc.Cleanup(ctx); err != nil {
+		t.Fatalf("Failed to cleanup container: %v", err)
+	}
+
+	if counter.cleanups != 2 {
+		t.Errorf("Expected successfully resolved instance to be cleaned up by the container, got %d cleanups", counter.cleanups)
+	}
+}
+
+type SubscribedAgent struct {
+	Counter *CounterService `fabric:"inject"`
+	Logger  LoggerEngine    `fabric:"inject,late"`
+}
+
+func TestAfterResolveReceivesWiredInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	calls := 0
+	errs := &Errors{}
+	errs.Add(Register[*CounterService](sc, AsSingleton()))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*SubscribedAgent](sc, AsSingleton(),
+		AfterResolve(func(ctx context.Context, agent *SubscribedAgent) error {
+			calls++
+			if agent.Counter == nil || agent.Counter.inits != 1 {
+				t.Errorf("Expected initialized counter to be injected before callback")
+			}
+			if agent.Logger == nil {
+				t.Errorf("Expected late field to be injected before callback")
+			}
+
+			// Callbacks run without the container lock and may resolve other services
+			counter, err := Resolve[*CounterService](ctx, sc)
+			if err != nil {
+				return err
+			}
+			if counter != agent.Counter {
+				t.Errorf("Expected callback to resolve the shared counter")
+			}
+			return nil
+		})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := Resolve[*SubscribedAgent](ctx, sc); err != nil {
+			t.Fatalf("Failed to resolve agent: %v", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected callback to run once for singleton, got %d", calls)
+	}
+}
+
+func TestAfterResolveRunsPerTransientInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	seen := make([]*CounterService, 0)
+	if err := Register[*CounterService](sc,
+		AfterResolve(func(ctx context.Context, counter *CounterService) error {
+			seen = append(seen, counter)
+			return nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := Resolve[*CounterService](ctx, sc); err != nil {
+			t.Fatalf("Failed to resolve counter: %v", err)
+		}
+	}
+
+	if len(seen) != 2 || seen[0] == seen[1] {
+		t.Errorf("Expected callback to run once per constructed instance, got %v", seen)
+	}
+}
+
+func TestAfterResolveFailureEvictsSingleton(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	failure := errors.New("subscription failed")
+	calls := 0
+	if err := Register[*CounterService](sc, AsSingleton(),
+		AfterResolve(func(ctx context.Context, counter *CounterService) error {
+			calls++
+			if calls == 1 {
+				return failure
+			}
+			return nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*CounterService](ctx, sc); !errors.Is(err, failure) {
+		t.Fatalf("Expected callback error, got %v", err)
+	}
+
+	if IsInstantiated[*CounterService](sc, "") {
+		t.Errorf("Expected singleton to be evicted after failed callback")
+	}
+
+	if _, err := Resolve[*CounterService](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected callback to run for the new instance, got %d calls", calls)
+	}
+}
+
+type SlowInitService struct {
+	delay    time.Duration
+	cleanups int
+}
+
+func (sis *SlowInitService) Init(ctx context.Context) error {
+	time.Sleep(sis.delay)
+	return nil
+}
+
+func (sis *SlowInitService) Cleanup(ctx context.Context) error {
+	sis.cleanups++
+	return nil
+}
+
+func TestConstructTimeoutCleansUpLateInit(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	slow := &SlowInitService{delay: 20 * time.Millisecond}
+	if err := Register[*SlowInitService](sc, WithConstructTimeout(5*time.Millisecond),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			return slow, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[*SlowInitService](ctx, sc); !errors.Is(err, ErrConstructTimeout) {
+		t.Fatalf("Expected ErrConstructTimeout, got %v", err)
+	}
+
+	if slow.cleanups != 1 {
+		t.Errorf("Expected instance initialized after the timeout to be cleaned up, got %d cleanups", slow.cleanups)
+	}
+
+	if err := sc.Cleanup(ctx); err != nil || slow.cleanups != 1 {
+		t.Errorf("Expected instance not to be cleaned up again, got %d cleanups", slow.cleanups)
+	}
+}
+
+type BlockingInitService struct {
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func (bis *BlockingInitService) Init(ctx context.Context) error {
+	close(bis.started)
+	<-bis.unblock
+	return nil
+}
+
+func (bis *BlockingInitService) Cleanup(ctx context.Context) error {
+	return nil
+}
+
+func TestSlowInitDoesNotBlockUnrelatedResolutions(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	blocking := &BlockingInitService{started: make(chan struct{}), unblock: make(chan struct{})}
+	errs := &Errors{}
+	errs.Add(Register[*BlockingInitService](sc, WithInstance(blocking)))
+	errs.Add(Register[*NamedLogger](sc, AsSingleton()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := Resolve[*BlockingInitService](ctx, sc)
+		done <- err
+	}()
+	<-blocking.started
+
+	unrelated := make(chan error)
+	go func() {
+		if err := Register[*CounterService](sc); err != nil {
+			unrelated <- err
+			return
+		}
+		_, err := Resolve[*NamedLogger](ctx, sc)
+		unrelated <- err
+	}()
+
+	select {
+	case err := <-unrelated:
+		if err != nil {
+			t.Errorf("Failed to register and resolve unrelated services: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected unrelated services not to wait for the running Init")
+	}
+
+	close(blocking.unblock)
+	if err := <-done; err != nil {
+		t.Errorf("Failed to resolve service with slow Init: %v", err)
+	}
+}
+
+func TestConcurrentResolutionsInitSharedInstanceOnce(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	counter := &CounterService{}
+	var factories sync.WaitGroup
+	factories.Add(2)
+	if err := Register[*CounterService](sc, AsSingleton(),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			// Both resolutions reach Init before either of them is cached
+			factories.Done()
+			factories.Wait()
+			return counter, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Resolve[*CounterService](ctx, sc); err != nil {
+				t.Errorf("Failed to resolve counter: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if counter.inits != 1 || counter.cleanups != 0 {
+		t.Errorf("Expected shared instance to be initialized once, got %d inits and %d cleanups", counter.inits, counter.cleanups)
+	}
+}
@@ -0,0 +1,115 @@
+// Package manifest applies declarative wiring manifests to a container.ServiceContainer.
+// A manifest describes which registered concrete types are mapped to which interfaces
+// under which names, allowing operators to re-wire named implementations, such as
+// switching a cache backend, without recompiling. A manifest can only select among
+// registered types; it never creates new registrations.
+//
+// Manifests are encoded as JSON:
+//
+//	{
+//		"bindings": [
+//			{"interface": "cache.Cache", "type": "*redis.Cache"},
+//			{"interface": "cache.Cache", "name": "local", "type": "*memory.Cache"}
+//		]
+//	}
+//
+// Example:
+//
+//	registry := manifest.Registry{
+//		"cache.Cache":   manifest.Type[cache.Cache](),
+//		"*redis.Cache":  manifest.Type[*redis.Cache](),
+//		"*memory.Cache": manifest.Type[*memory.Cache](),
+//	}
+//
+//	m, err := manifest.Load(file)
+//	if err != nil {
+//		return err
+//	}
+//	if err := m.Apply(sc, registry); err != nil {
+//		return err
+//	}
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/mwantia/fabric/pkg/container"
+)
+
+// Registry maps the type names used in a manifest to their runtime types.
+type Registry map[string]reflect.Type
+
+// Type returns the runtime type of T, including interface types, for use in a Registry.
+func Type[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Manifest is a declarative description of interface bindings.
+type Manifest struct {
+	// Bindings contains the interface bindings applied in order
+	Bindings []Binding `json:"bindings"`
+}
+
+// Binding maps an interface under a name to a registered concrete type.
+type Binding struct {
+	// Interface is the registry name of the interface type
+	Interface string `json:"interface"`
+
+	// Name is the name the interface is mapped under, empty for the default mapping
+	Name string `json:"name,omitempty"`
+
+	// Type is the registry name of the registered concrete type
+	Type string `json:"type"`
+
+	// TypeName is the name the concrete type was registered under, empty for the default registration
+	TypeName string `json:"type_name,omitempty"`
+}
+
+// Load decodes a JSON manifest from the provided reader. Unknown fields are rejected.
+func Load(r io.Reader) (*Manifest, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	manifest := &Manifest{}
+	if err := decoder.Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// Validate checks that every type name referenced by the manifest exists in the registry.
+// All missing type names are collected and returned as a single error.
+func (m *Manifest) Validate(registry Registry) error {
+	errs := &container.Errors{}
+	for i, binding := range m.Bindings {
+		for _, typeName := range []string{binding.Interface, binding.Type} {
+			if _, exists := registry[typeName]; !exists {
+				errs.Add(fmt.Errorf("binding %d references unknown type '%s'", i, typeName))
+			}
+		}
+	}
+
+	return errs.Errors()
+}
+
+// Apply validates the manifest and binds every interface to its registered concrete
+// type in order. Bindings referencing unregistered types or types not implementing the
+// interface fail; the errors of all failing bindings are returned as a single error.
+func (m *Manifest) Apply(sc *container.ServiceContainer, registry Registry) error {
+	if err := m.Validate(registry); err != nil {
+		return fmt.Errorf("failed to validate manifest: %w", err)
+	}
+
+	errs := &container.Errors{}
+	for i, binding := range m.Bindings {
+		if err := sc.BindType(registry[binding.Interface], binding.Name, registry[binding.Type], binding.TypeName); err != nil {
+			errs.Add(fmt.Errorf("failed to apply binding %d: %w", i, err))
+		}
+	}
+
+	return errs.Errors()
+}
@@ -0,0 +1,98 @@
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mwantia/fabric/pkg/container"
+)
+
+type Cache interface {
+	Backend() string
+}
+
+type RedisCache struct{}
+
+func (rc *RedisCache) Backend() string { return "redis" }
+
+type MemoryCache struct{}
+
+func (mc *MemoryCache) Backend() string { return "memory" }
+
+var registry = Registry{
+	"Cache":        Type[Cache](),
+	"*RedisCache":  Type[*RedisCache](),
+	"*MemoryCache": Type[*MemoryCache](),
+}
+
+func TestApplyManifest(t *testing.T) {
+	sc := container.NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &container.Errors{}
+	errs.Add(container.Register[*RedisCache](sc, container.With[Cache]()))
+	errs.Add(container.Register[*MemoryCache](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	m, err := Load(strings.NewReader(`{
+		"bindings": [
+			{"interface": "Cache", "type": "*MemoryCache"},
+			{"interface": "Cache", "name": "remote", "type": "*RedisCache"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+
+	if err := m.Apply(sc, registry); err != nil {
+		t.Fatalf("Failed to apply manifest: %v", err)
+	}
+
+	cache, err := container.Resolve[Cache](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve cache: %v", err)
+	}
+
+	if backend := cache.Backend(); backend != "memory" {
+		t.Errorf("Expected manifest to re-wire default cache to memory, got '%s'", backend)
+	}
+
+	remote, err := container.ResolveName[Cache](ctx, sc, "remote")
+	if err != nil {
+		t.Fatalf("Failed to resolve remote cache: %v", err)
+	}
+
+	if backend := remote.Backend(); backend != "redis" {
+		t.Errorf("Expected remote cache to be redis, got '%s'", backend)
+	}
+}
+
+func TestValidateManifest(t *testing.T) {
+	m := &Manifest{
+		Bindings: []Binding{
+			{Interface: "Cache", Type: "*FileCache"},
+		},
+	}
+
+	err := m.Validate(registry)
+	if err == nil || !strings.Contains(err.Error(), "*FileCache") {
+		t.Errorf("Expected unknown type to be reported, got %v", err)
+	}
+}
+
+func TestApplyManifestUnregisteredType(t *testing.T) {
+	sc := container.NewServiceContainer()
+
+	m := &Manifest{
+		Bindings: []Binding{
+			{Interface: "Cache", Type: "*RedisCache"},
+		},
+	}
+
+	if err := m.Apply(sc, registry); err == nil {
+		t.Error("Expected binding of unregistered type to fail")
+	}
+}
@@ -12,6 +12,13 @@ import (
 // Middlewares are executed in the order they are registered and can be used for
 // cross-cutting concerns such as logging, caching, validation, or proxying.
 //
+// Middlewares only run when an instance is constructed, or when a cached singleton is
+// first resolved via another type or name mapped to its registration. Cached singletons
+// and scoped services are returned as processed by the middlewares for the requested
+// type, so wrapping middlewares never wrap the same instance twice for a type, and a
+// wrapper returned for an interface never replaces the concrete instance. Middlewares
+// that need to observe cache hits can implement MiddlewareServiceV2 instead.
+//
 // Example:
 //
 //	type LoggingMiddleware struct{}
@@ -37,3 +44,111 @@ type MiddlewareService interface {
 	// and instance is the resolved service instance. Returns the processed instance or an error.
 	Process(context.Context, reflect.Type, any) (any, error)
 }
+
+// MiddlewareInfo describes the resolution a MiddlewareServiceV2 is invoked for.
+type MiddlewareInfo struct {
+	// Type is the type the service is being resolved for
+	Type reflect.Type
+
+	// Name is the name of the registration the service is resolved from
+	Name string
+
+	// Singleton indicates whether the registration is a singleton
+	Singleton bool
+
+	// CacheHit indicates whether the instance was returned from the singleton or scope cache
+	// instead of being constructed
+	CacheHit bool
+}
+
+// MiddlewareServiceV2 is a richer variant of MiddlewareService that receives information
+// about the resolution it is invoked for. Unlike MiddlewareService, it is also invoked
+// when a cached singleton or scoped instance is returned, with CacheHit set. Instances
+// returned for cache hits are passed to the caller, but never replace the cached instance.
+//
+// Both variants coexist: middlewares added as MiddlewareService are adapted automatically
+// and keep only running during construction. Registering a MiddlewareServiceV2 disables
+// the lock-free lookup of cached instances, since every cache hit has to be processed.
+//
+// Example:
+//
+//	type ConstructionLogger struct{}
+//
+//	func (cl *ConstructionLogger) Process(ctx context.Context, info MiddlewareInfo, instance any) (any, error) {
+//		if !info.CacheHit {
+//			log.Printf("Constructed '%s' with name '%s'", info.Type, info.Name)
+//		}
+//		return instance, nil
+//	}
+type MiddlewareServiceV2 interface {
+	// Process handles the middleware processing and returns the potentially modified instance.
+	Process(context.Context, MiddlewareInfo, any) (any, error)
+}
+
+// middlewareAdapter adapts a MiddlewareService to the MiddlewareServiceV2 interface,
+// skipping cache hits to retain its behavior.
+type middlewareAdapter struct {
+	middleware MiddlewareService
+}
+
+// Process invokes the adapted middleware for constructed instances only.
+func (ma *middlewareAdapter) Process(ctx context.Context, info MiddlewareInfo, instance any) (any, error) {
+	if info.CacheHit {
+		return instance, nil
+	}
+
+	return ma.middleware.Process(ctx, info.Type, instance)
+}
+
+// adaptMiddlewares adapts the provided middlewares to the MiddlewareServiceV2 interface.
+func adaptMiddlewares(middlewares []MiddlewareService) []MiddlewareServiceV2 {
+	adapted := make([]MiddlewareServiceV2, 0, len(middlewares))
+	for _, middleware := range middlewares {
+		adapted = append(adapted, &middlewareAdapter{middleware: middleware})
+	}
+
+	return adapted
+}
+
+// processesCacheHits reports whether any of the provided middlewares processes cache hits.
+func processesCacheHits(middlewares []MiddlewareServiceV2) bool {
+	for _, middleware := range middlewares {
+		if _, adapted := middleware.(*middlewareAdapter); !adapted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typedMiddleware adapts a strongly typed middleware function to the
+// MiddlewareService interface.
+type typedMiddleware[T any] struct {
+	process func(context.Context, T) (T, error)
+}
+
+// TypedMiddleware wraps a strongly typed middleware function into a MiddlewareService.
+// The function is only invoked for resolved instances that are assignable to T,
+// which removes the need for type assertions within the middleware itself.
+// Instances that are not assignable to T are returned unchanged.
+//
+// Example:
+//
+//	container.AddMiddleware(TypedMiddleware[Database](func(ctx context.Context, db Database) (Database, error) {
+//		return &TracingDatabase{Database: db}, nil
+//	}))
+func TypedMiddleware[T any](process func(context.Context, T) (T, error)) MiddlewareService {
+	return &typedMiddleware[T]{
+		process: process,
+	}
+}
+
+// Process invokes the wrapped middleware function if the instance is assignable to T.
+func (tm *typedMiddleware[T]) Process(ctx context.Context, serviceType reflect.Type, instance any) (any, error) {
+	typed, ok := instance.(T)
+	if !ok {
+		return instance, nil
+	}
+
+	return tm.process(ctx, typed)
+}
@@ -0,0 +1,70 @@
+package container
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// constructionContextKey is the context key used to pass the construction start
+// time of an instance to middlewares.
+type constructionContextKey struct{}
+
+// LoggingMiddleware is a middleware that logs every constructed service together with
+// the duration of its construction, measured from the start of its factory call until
+// the middleware is reached. It is safe to add globally and can be configured to only
+// log the first construction of each type.
+type LoggingMiddleware struct {
+	log       func(format string, args ...any)
+	firstOnly bool
+	seen      sync.Map
+}
+
+// LoggingOption is a function type used to configure a LoggingMiddleware.
+type LoggingOption func(*LoggingMiddleware)
+
+// NewLoggingMiddleware creates a new LoggingMiddleware that writes its messages
+// using the provided printf-style log function.
+//
+// Example:
+//
+//	container.AddMiddleware(NewLoggingMiddleware(log.Printf))
+//
+//	// Only log the first construction of each type
+//	container.AddMiddleware(NewLoggingMiddleware(log.Printf, WithFirstConstructionOnly()))
+func NewLoggingMiddleware(log func(format string, args ...any), opts ...LoggingOption) *LoggingMiddleware {
+	lm := &LoggingMiddleware{
+		log: log,
+	}
+	for _, opt := range opts {
+		opt(lm)
+	}
+
+	return lm
+}
+
+// WithFirstConstructionOnly configures the LoggingMiddleware to only log the first
+// construction of each service type, skipping repeated constructions of transients.
+func WithFirstConstructionOnly() LoggingOption {
+	return func(lm *LoggingMiddleware) {
+		lm.firstOnly = true
+	}
+}
+
+// Process logs the constructed service type and its construction duration.
+func (lm *LoggingMiddleware) Process(ctx context.Context, serviceType reflect.Type, instance any) (any, error) {
+	if lm.firstOnly {
+		if _, seen := lm.seen.LoadOrStore(serviceType, struct{}{}); seen {
+			return instance, nil
+		}
+	}
+
+	if started, ok := ctx.Value(constructionContextKey{}).(time.Time); ok {
+		lm.log("constructed '%s' (%T) in %s", serviceType, instance, time.Since(started))
+	} else {
+		lm.log("constructed '%s' (%T)", serviceType, instance)
+	}
+
+	return instance, nil
+}
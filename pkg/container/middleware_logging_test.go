@@ -0,0 +1,35 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	messages := make([]string, 0)
+	sc.AddMiddleware(NewLoggingMiddleware(func(format string, args ...any) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}, WithFirstConstructionOnly()))
+
+	for i := 0; i < 3; i++ {
+		if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+			t.Fatalf("Failed to resolve logger: %v", err)
+		}
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected a single log message, got %v", messages)
+	}
+
+	if !strings.Contains(messages[0], "container.LoggerEngine") || !strings.Contains(messages[0], " in ") {
+		t.Errorf("Expected log message to contain type and duration, got %q", messages[0])
+	}
+}
@@ -0,0 +1,210 @@
+package container
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type PrefixLoggerService struct {
+	LoggerEngine
+	prefix string
+}
+
+func TestTypedMiddleware(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	calls := 0
+	sc.AddMiddleware(TypedMiddleware[LoggerEngine](func(ctx context.Context, logger LoggerEngine) (LoggerEngine, error) {
+		calls++
+		return &PrefixLoggerService{LoggerEngine: logger, prefix: "test"}, nil
+	}))
+
+	logger, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if _, ok := logger.(*PrefixLoggerService); !ok {
+		t.Errorf("Expected logger to be wrapped by middleware, got %T", logger)
+	}
+
+	if _, err := Resolve[EncryptEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve encrypt: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected middleware to be called once, got %d", calls)
+	}
+}
+
+func TestMiddlewareRunsOncePerSingleton(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine](), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	calls := 0
+	sc.AddMiddleware(TypedMiddleware[LoggerEngine](func(ctx context.Context, logger LoggerEngine) (LoggerEngine, error) {
+		calls++
+		return &PrefixLoggerService{LoggerEngine: logger, prefix: "test"}, nil
+	}))
+
+	first, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	for range 3 {
+		logger, err := ResolveName[LoggerEngine](ctx, sc, "")
+		if err != nil {
+			t.Fatalf("Failed to resolve logger: %v", err)
+		}
+
+		if logger != first {
+			t.Error("Expected cached singleton to be the already processed instance")
+		}
+
+		prefixed, ok := logger.(*PrefixLoggerService)
+		if !ok {
+			t.Fatalf("Expected logger to be wrapped by middleware, got %T", logger)
+		}
+
+		if _, ok := prefixed.LoggerEngine.(*LoggerService); !ok {
+			t.Errorf("Expected logger to be wrapped exactly once, got %T", prefixed.LoggerEngine)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected middleware to run once across resolutions, got %d", calls)
+	}
+}
+
+type recordingMiddleware struct {
+	name  string
+	order *[]string
+}
+
+func (rm *recordingMiddleware) Process(ctx context.Context, serviceType reflect.Type, instance any) (any, error) {
+	*rm.order = append(*rm.order, rm.name)
+	return instance, nil
+}
+
+func TestRegistrationMiddlewareOrder(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	order := make([]string, 0)
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc,
+		With[LoggerEngine](),
+		WithMiddleware(&recordingMiddleware{name: "registration", order: &order})))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	sc.AddMiddleware(&recordingMiddleware{name: "global", order: &order})
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "global" || order[1] != "registration" {
+		t.Errorf("Expected middlewares to run as [global registration], got %v", order)
+	}
+
+	order = order[:0]
+	if _, err := Resolve[EncryptEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve encrypt: %v", err)
+	}
+
+	if len(order) != 1 || order[0] != "global" {
+		t.Errorf("Expected only global middleware to run, got %v", order)
+	}
+}
+
+func TestNamedMiddlewareReplacement(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, With[LoggerEngine]()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	order := make([]string, 0)
+	sc.AddNamedMiddleware("first", &recordingMiddleware{name: "first", order: &order})
+	sc.AddNamedMiddleware("second", &recordingMiddleware{name: "second", order: &order})
+	sc.AddNamedMiddleware("first", &recordingMiddleware{name: "replaced", order: &order})
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "replaced" || order[1] != "second" {
+		t.Errorf("Expected middlewares to run as [replaced second], got %v", order)
+	}
+}
+
+type infoRecordingMiddleware struct {
+	infos []MiddlewareInfo
+}
+
+func (irm *infoRecordingMiddleware) Process(ctx context.Context, info MiddlewareInfo, instance any) (any, error) {
+	irm.infos = append(irm.infos, info)
+	return instance, nil
+}
+
+func TestMiddlewareV2ReceivesInfo(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*NamedLogger](sc, WithName[LoggerEngine]("primary"), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	calls := 0
+	sc.AddMiddleware(TypedMiddleware[LoggerEngine](func(ctx context.Context, logger LoggerEngine) (LoggerEngine, error) {
+		calls++
+		return logger, nil
+	}))
+
+	recorder := &infoRecordingMiddleware{}
+	sc.AddMiddlewareV2(recorder)
+
+	for range 2 {
+		if _, err := ResolveName[LoggerEngine](ctx, sc, "primary"); err != nil {
+			t.Fatalf("Failed to resolve logger: %v", err)
+		}
+	}
+
+	if len(recorder.infos) != 2 {
+		t.Fatalf("Expected middleware to be invoked for construction and cache hit, got %d", len(recorder.infos))
+	}
+
+	first, second := recorder.infos[0], recorder.infos[1]
+	if first.Type != typeKey[LoggerEngine]() || first.Name != "primary" || !first.Singleton || first.CacheHit {
+		t.Errorf("Expected construction info, got %+v", first)
+	}
+
+	if !second.CacheHit {
+		t.Errorf("Expected cache hit info, got %+v", second)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected adapted middleware to skip cache hits, got %d calls", calls)
+	}
+}
@@ -0,0 +1,73 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+)
+
+// Validator is implemented by services that can validate their own state.
+// Resolved instances implementing this interface are validated by the ValidationMiddleware.
+type Validator interface {
+	// Validate returns an error if the service is not in a valid state
+	Validate() error
+}
+
+// ValidationMiddleware is a middleware that validates resolved instances implementing
+// the Validator interface. By default, a failing validation is fatal and causes the
+// resolution to fail. If a logger is provided via WithValidationLogger, failures are
+// logged instead and the instance is returned unchanged.
+type ValidationMiddleware struct {
+	logger *slog.Logger
+}
+
+// ValidationOption is a function type used to configure a ValidationMiddleware.
+type ValidationOption func(*ValidationMiddleware)
+
+// NewValidationMiddleware creates a new ValidationMiddleware configured with the
+// provided options. Without options, failing validations are fatal.
+//
+// Example:
+//
+//	// Fail resolution on validation errors
+//	container.AddMiddleware(NewValidationMiddleware())
+//
+//	// Only log validation errors
+//	container.AddMiddleware(NewValidationMiddleware(WithValidationLogger(slog.Default())))
+func NewValidationMiddleware(opts ...ValidationOption) *ValidationMiddleware {
+	vm := &ValidationMiddleware{}
+	for _, opt := range opts {
+		opt(vm)
+	}
+
+	return vm
+}
+
+// WithValidationLogger makes validation failures non-fatal. Instead of failing the
+// resolution, failures are logged as warnings using the provided logger.
+func WithValidationLogger(logger *slog.Logger) ValidationOption {
+	return func(vm *ValidationMiddleware) {
+		vm.logger = logger
+	}
+}
+
+// Process validates the instance if it implements Validator.
+func (vm *ValidationMiddleware) Process(ctx context.Context, serviceType reflect.Type, instance any) (any, error) {
+	validator, ok := instance.(Validator)
+	if !ok {
+		return instance, nil
+	}
+
+	if err := validator.Validate(); err != nil {
+		if vm.logger == nil {
+			return nil, fmt.Errorf("service validation failed for '%s': %w", serviceType, err)
+		}
+
+		vm.logger.WarnContext(ctx, "service validation failed",
+			"type", serviceType.String(),
+			"error", err)
+	}
+
+	return instance, nil
+}
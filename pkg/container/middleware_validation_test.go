@@ -0,0 +1,63 @@
+package container
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type ValidatedService struct {
+	valid bool
+}
+
+func (vs *ValidatedService) Validate() error {
+	if !vs.valid {
+		return errors.New("service is invalid")
+	}
+	return nil
+}
+
+func TestValidationMiddleware(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*ValidatedService](sc, WithInstance(&ValidatedService{valid: true})))
+	errs.Add(Register[*ValidatedService](sc, AsNamed("invalid"), WithInstance(&ValidatedService{valid: false})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	sc.AddMiddleware(NewValidationMiddleware())
+
+	if _, err := Resolve[*ValidatedService](ctx, sc); err != nil {
+		t.Errorf("Expected valid service to resolve, got %v", err)
+	}
+
+	if _, err := ResolveName[*ValidatedService](ctx, sc, "invalid"); err == nil {
+		t.Error("Expected invalid service to fail resolution")
+	}
+}
+
+func TestValidationMiddlewareWithLogger(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*ValidatedService](sc, WithInstance(&ValidatedService{valid: false})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	sc.AddMiddleware(NewValidationMiddleware(WithValidationLogger(slog.New(slog.NewTextHandler(buf, nil)))))
+
+	if _, err := Resolve[*ValidatedService](ctx, sc); err != nil {
+		t.Errorf("Expected non-fatal validation to resolve, got %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "service is invalid") {
+		t.Errorf("Expected validation failure to be logged, got %q", buf.String())
+	}
+}
@@ -0,0 +1,217 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Module is a function that registers a cohesive set of services with the container.
+// Modules standardize how wiring is split across packages, allowing each package to
+// expose its own registrations and the application bootstrap to install them together.
+//
+// Example:
+//
+//	// package logging
+//	func Module(sc *container.ServiceContainer) error {
+//		return container.Register[*ConsoleLogger](sc,
+//			container.With[Logger](),
+//			container.AsSingleton())
+//	}
+//
+//	// package main
+//	err := sc.Install(logging.Module, database.Module, web.Module)
+type Module func(*ServiceContainer) error
+
+// moduleRecorder records the registrations made with the staging container of a module
+// while it is reloaded.
+type moduleRecorder struct {
+	// services contains the recorded registrations in registration order
+	services []*RegistrationService
+}
+
+// Install runs each of the provided modules against the container in the order they
+// are provided. All modules are installed even if a previous module failed; the errors
+// of every failing module are collected and returned as a single error.
+//
+// Example:
+//
+//	if err := sc.Install(logging.Module, database.Module); err != nil {
+//		log.Fatalf("Failed to install modules: %v", err)
+//	}
+func (sc *ServiceContainer) Install(modules ...Module) error {
+	errs := &Errors{}
+	for i, module := range modules {
+		if module == nil {
+			errs.Add(fmt.Errorf("module at index %d is nil", i))
+			continue
+		}
+
+		if err := module(sc); err != nil {
+			errs.Add(fmt.Errorf("failed to install module at index %d: %w", i, err))
+		}
+	}
+
+	return errs.Errors()
+}
+
+// ReloadModule installs the provided module under the given token, atomically replacing
+// the registrations previously installed under the same token, e.g. after the configuration
+// the module captures has changed. The first call for a token simply installs the module.
+//
+// The module is run against a staging child container, which falls back to this container
+// for resolutions, so registrations made concurrently with this container are neither
+// recorded as part of the module nor lost. If the module fails, its registrations are
+// discarded and the container is left unchanged. Otherwise, in one locked operation, the
+// previous registrations of the token are removed together with their cached singletons
+// and the new registrations are stored. Finally, the removed singletons implementing
+// LifecycleService are cleaned up.
+//
+// Only registrations are applied to the container; aliases, middlewares, decorators and
+// tag processors added by the module to the staging container are discarded, as are
+// instances it resolved from its own registrations.
+//
+// Example:
+//
+//	func DatabaseModule(cfg Config) Module {
+//		return func(sc *ServiceContainer) error {
+//			return Register[*Database](sc, AsSingleton(), WithInstance(NewDatabase(cfg)))
+//		}
+//	}
+//
+//	err := container.ReloadModule(ctx, "database", DatabaseModule(reloadedConfig))
+func (sc *ServiceContainer) ReloadModule(ctx context.Context, token string, module Module) error {
+	if module == nil {
+		return fmt.Errorf("module '%s' is nil", token)
+	}
+
+	staging := sc.CreateChild()
+	staging.recorder = &moduleRecorder{}
+
+	errs := &Errors{}
+	if err := module(staging); err != nil {
+		errs.Add(fmt.Errorf("failed to reload module '%s': %w", token, err))
+		errs.Add(staging.Cleanup(ctx))
+		return errs.Errors()
+	}
+	errs.Add(staging.Cleanup(ctx))
+
+	services := staging.recorder.services
+
+	sc.mu.Lock()
+	removed := sc.removeRegistrations(sc.modules[token])
+	for _, service := range services {
+		sc.storeRegistration(service)
+		if processesCacheHits(service.Middlewares) {
+			sc.cacheHitMiddlewares.Store(true)
+		}
+
+		// Modules reloaded by a module being reloaded itself are part of the enclosing module
+		if sc.recorder != nil {
+			sc.recorder.services = append(sc.recorder.services, service)
+		}
+	}
+	sc.modules[token] = services
+	sc.mu.Unlock()
+
+	for i := len(removed) - 1; i >= 0; i-- {
+		if err := removed[i].Cleanup(ctx); err != nil {
+			errs.Add(fmt.Errorf("error during module cleanup: %w", err))
+		}
+	}
+
+	return errs.Errors()
+}
+
+// removeRegistrations removes the provided registrations from all type keys they are
+// stored under, promoting the highest priority contender of vacated unnamed interface
+// mappings. Cached singletons of the removed registrations are dropped and those
+// implementing LifecycleService are removed from the container cleanup and returned,
+// in initialization order. The caller must hold the lock.
+func (sc *ServiceContainer) removeRegistrations(services []*RegistrationService) []LifecycleService {
+	removed := make(map[*RegistrationService]bool, len(services))
+	for _, service := range services {
+		removed[service] = true
+	}
+
+	instances := make(map[any]bool)
+	for key, serviceMaps := range sc.services {
+		for name, service := range serviceMaps {
+			if !removed[service] {
+				continue
+			}
+
+			delete(serviceMaps, name)
+			sc.instances.Delete(instanceKey{key: key, name: name})
+		}
+	}
+
+	for slot := range sc.failures {
+		if removed[slot.service] {
+			delete(sc.failures, slot)
+		}
+	}
+
+	for slot, instance := range sc.singletons {
+		if removed[slot.service] {
+			if reflect.TypeOf(instance).Comparable() {
+				instances[instance] = true
+			}
+			delete(sc.singletons, slot)
+		}
+	}
+
+	// Pre-created instances are initialized without being cached for non-singleton registrations
+	for service := range removed {
+		if service.instance != nil && reflect.TypeOf(service.instance).Comparable() {
+			instances[service.instance] = true
+		}
+	}
+
+	for view := range sc.views {
+		if removed[view.slot.service] {
+			delete(sc.views, view)
+			sc.instances.Delete(instanceKey{key: view.key, name: view.name})
+		}
+	}
+
+	for key, contenders := range sc.contenders {
+		remaining := make([]*RegistrationService, 0, len(contenders))
+		for _, service := range contenders {
+			if !removed[service] {
+				remaining = append(remaining, service)
+			}
+		}
+		sc.contenders[key] = remaining
+
+		if _, exists := sc.services[key][""]; !exists && len(remaining) > 0 {
+			winner := 0
+			for i, service := range remaining {
+				if service.Priority > remaining[winner].Priority {
+					winner = i
+				}
+			}
+
+			if sc.services[key] == nil {
+				sc.services[key] = make(map[string]*RegistrationService)
+			}
+			sc.services[key][""] = remaining[winner]
+			sc.instances.Delete(instanceKey{key: key, name: ""})
+			sc.contenders[key] = append(remaining[:winner:winner], remaining[winner+1:]...)
+		}
+	}
+
+	lifecycles := make([]LifecycleService, 0)
+	kept := make([]lifecycleEntry, 0, len(sc.lifecycles))
+	for _, entry := range sc.lifecycles {
+		if reflect.TypeOf(entry.service).Comparable() && instances[entry.service] {
+			lifecycles = append(lifecycles, entry.service)
+			delete(sc.initialized, entry.service)
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	sc.lifecycles = kept
+
+	return lifecycles
+}
@@ -0,0 +1,262 @@
+package container
+
+import (
+	"errors"
+	"testing"
+)
+
+func LoggerModule(sc *ServiceContainer) error {
+	return Register[*LoggerService](sc,
+		With[LoggerEngine](),
+		AsSingleton())
+}
+
+func EncryptModule(sc *ServiceContainer) error {
+	return Register[*EncryptService](sc,
+		With[EncryptEngine](),
+		AsSingleton())
+}
+
+func AgentModule(sc *ServiceContainer) error {
+	return Register[*Agent](sc, AsSingleton())
+}
+
+func TestInstallModules(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := sc.Install(LoggerModule, EncryptModule, AgentModule); err != nil {
+		t.Fatalf("Failed to install modules: %v", err)
+	}
+
+	agent, err := Resolve[*Agent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger == nil || agent.Encrypt == nil {
+		t.Error("Dependencies were not successfully injected")
+	}
+}
+
+func TestInstallModulesAggregatesErrors(t *testing.T) {
+	sc := NewServiceContainer()
+
+	failing := func(sc *ServiceContainer) error {
+		return Register[LoggerService](sc, With[LoggerEngine]())
+	}
+
+	err := sc.Install(failing, EncryptModule, nil)
+	if err == nil {
+		t.Fatal("Expected module installation to fail")
+	}
+
+	if _, err := Resolve[EncryptEngine](t.Context(), sc); err != nil {
+		t.Errorf("Expected remaining modules to be installed: %v", err)
+	}
+}
+
+func CounterModule(counter *CounterService, named bool) Module {
+	return func(sc *ServiceContainer) error {
+		opts := []RegistrationOption{WithInstance(counter), With[CounterEngine]()}
+		if named {
+			opts = append(opts, WithName[CounterEngine]("legacy"))
+		}
+		return Register[*CounterService](sc, opts...)
+	}
+}
+
+func TestReloadModule(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	previous := &CounterService{}
+	if err := sc.ReloadModule(ctx, "counter", CounterModule(previous, true)); err != nil {
+		t.Fatalf("Failed to install module: %v", err)
+	}
+
+	if err := sc.Install(LoggerModule); err != nil {
+		t.Fatalf("Failed to install modules: %v", err)
+	}
+
+	if _, err := Resolve[CounterEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	reloaded := &CounterService{}
+	if err := sc.ReloadModule(ctx, "counter", CounterModule(reloaded, false)); err != nil {
+		t.Fatalf("Failed to reload module: %v", err)
+	}
+
+	if previous.cleanups != 1 {
+		t.Errorf("Expected previous instance to be cleaned up once, got %d", previous.cleanups)
+	}
+
+	counter, err := Resolve[CounterEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve counter: %v", err)
+	}
+
+	if counter != reloaded {
+		t.Error("Expected reloaded instance to be resolved")
+	}
+
+	if _, err := ResolveName[CounterEngine](ctx, sc, "legacy"); err == nil {
+		t.Error("Expected registrations removed from the module to be gone")
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Errorf("Expected registrations of other modules to be kept: %v", err)
+	}
+}
+
+func TestReloadModuleFailureKeepsRegistrations(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := sc.Install(LoggerModule); err != nil {
+		t.Fatalf("Failed to install modules: %v", err)
+	}
+
+	failing := func(sc *ServiceContainer) error {
+		return Register[LoggerService](sc, With[LoggerEngine]())
+	}
+
+	if err := sc.ReloadModule(ctx, "logger", failing); err == nil {
+		t.Fatal("Expected reload of failing module to fail")
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Errorf("Expected registrations to be unchanged: %v", err)
+	}
+}
+
+func NestingModule(logger *NamedLogger, failure error) Module {
+	return func(sc *ServiceContainer) error {
+		nested := func(sc *ServiceContainer) error {
+			return Register[*NamedLogger](sc, WithInstance(logger), With[LoggerEngine]())
+		}
+
+		if err := sc.Install(nested); err != nil {
+			return err
+		}
+
+		return failure
+	}
+}
+
+func TestReloadModuleFailureDiscardsNestedRegistrations(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	previous := &NamedLogger{name: "previous"}
+	if err := sc.ReloadModule(ctx, "nesting", NestingModule(previous, nil)); err != nil {
+		t.Fatalf("Failed to install module: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	failure := errors.New("invalid configuration")
+	if err := sc.ReloadModule(ctx, "nesting", NestingModule(&NamedLogger{name: "reloaded"}, failure)); !errors.Is(err, failure) {
+		t.Fatalf("Expected reload of failing module to fail, got %v", err)
+	}
+
+	logger, err := Resolve[LoggerEngine](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if logger != LoggerEngine(previous) {
+		t.Errorf("Expected registrations of the nested module to be discarded, got '%s'", logger.(*NamedLogger).name)
+	}
+
+	concrete, err := Resolve[*NamedLogger](ctx, sc)
+	if err != nil || concrete != previous {
+		t.Errorf("Expected concrete registration to be kept: %v", err)
+	}
+}
+
+func TestReloadModuleTokens(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	first, second := &CounterService{}, &CounterService{}
+	errs := &Errors{}
+	errs.Add(sc.ReloadModule(ctx, "first", CounterModule(first, true)))
+	errs.Add(sc.ReloadModule(ctx, "second", func(sc *ServiceContainer) error {
+		return Register[*CounterService](sc, WithInstance(second), WithName[CounterEngine]("second"))
+	}))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to install modules: %v", err)
+	}
+
+	// Modules created by the same function are only replaced if they share a token
+	if err := sc.ReloadModule(ctx, "other", CounterModule(&CounterService{}, false)); err != nil {
+		t.Fatalf("Failed to install module: %v", err)
+	}
+
+	legacy, err := ResolveName[CounterEngine](ctx, sc, "legacy")
+	if err != nil || legacy != first {
+		t.Errorf("Expected registrations of another token to be kept: %v", err)
+	}
+
+	if counter, err := ResolveName[CounterEngine](ctx, sc, "second"); err != nil || counter != second {
+		t.Errorf("Expected registrations of another token to be kept: %v", err)
+	}
+}
+
+func TestReloadModuleKeepsConcurrentRegistrations(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	reloads := []struct {
+		name    string
+		failure error
+	}{
+		{name: "succeeding", failure: nil},
+		{name: "failing", failure: errors.New("invalid configuration")},
+	}
+
+	for _, reload := range reloads {
+		started := make(chan struct{})
+		unblock := make(chan struct{})
+		module := func(sc *ServiceContainer) error {
+			close(started)
+			<-unblock
+			if err := Register[*CounterService](sc, WithInstance(&CounterService{}), With[CounterEngine]()); err != nil {
+				return err
+			}
+			return reload.failure
+		}
+
+		done := make(chan error)
+		go func() {
+			done <- sc.ReloadModule(ctx, "counter", module)
+		}()
+		<-started
+
+		// Registrations made while the module runs belong to the container, not to the module
+		if err := Register[*NamedLogger](sc, WithName[LoggerEngine](reload.name)); err != nil {
+			t.Fatalf("Failed to complete service registration: %v", err)
+		}
+		close(unblock)
+
+		if err := <-done; !errors.Is(err, reload.failure) {
+			t.Fatalf("Expected %s reload to return %v, got %v", reload.name, reload.failure, err)
+		}
+	}
+
+	// Reloading the module again only replaces its own registrations
+	if err := sc.ReloadModule(ctx, "counter", CounterModule(&CounterService{}, false)); err != nil {
+		t.Fatalf("Failed to reload module: %v", err)
+	}
+
+	for _, reload := range reloads {
+		if _, err := ResolveName[LoggerEngine](ctx, sc, reload.name); err != nil {
+			t.Errorf("Expected registration made during the %s reload to be kept: %v", reload.name, err)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package container
+
+// SetNameNormalizer sets a policy normalizing registration names, applied consistently
+// to names at registration, aliasing and resolution, including names used in fabric
+// tags. This allows names to be matched e.g. case-insensitively. By default, names are
+// matched exactly. The normalizer only applies to registrations added after it was set,
+// so it should be set before registering any named services. Child containers and
+// scopes inherit the normalizer of their parent at creation.
+//
+// Example:
+//
+//	container.SetNameNormalizer(func(name string) string {
+//		return strings.ToLower(strings.TrimSpace(name))
+//	})
+func (sc *ServiceContainer) SetNameNormalizer(normalizer func(string) string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.normalizer = normalizer
+}
+
+// normalizeName applies the name normalizer to the provided name, if one is set.
+// The caller must hold the container lock.
+func (sc *ServiceContainer) normalizeName(name string) string {
+	if sc.normalizer == nil {
+		return name
+	}
+
+	return sc.normalizer(name)
+}
@@ -0,0 +1,69 @@
+package container
+
+import (
+	"strings"
+	"testing"
+)
+
+type NormalizedAgent struct {
+	Logger LoggerEngine `fabric:"inject: Console "`
+}
+
+func TestNameNormalizer(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	sc.SetNameNormalizer(func(name string) string {
+		return strings.ToLower(strings.TrimSpace(name))
+	})
+
+	errs := &Errors{}
+	errs.Add(Register[*LoggerService](sc, WithName[LoggerEngine]("Console"), AsSingleton()))
+	errs.Add(Register[*NormalizedAgent](sc))
+	errs.Add(Alias[LoggerEngine](sc, "DEFAULT", "console"))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	logger, err := ResolveName[LoggerEngine](ctx, sc, "CONSOLE")
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	for _, name := range []string{"console", " Console", "default"} {
+		resolved, err := ResolveName[LoggerEngine](ctx, sc, name)
+		if err != nil {
+			t.Fatalf("Failed to resolve logger with name '%s': %v", name, err)
+		}
+		if resolved != logger {
+			t.Errorf("Expected name '%s' to resolve the same singleton", name)
+		}
+	}
+
+	agent, err := Resolve[*NormalizedAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger != logger {
+		t.Error("Expected tag injection to use the normalized name")
+	}
+
+	if !IsInstantiated[LoggerEngine](sc, "Console") {
+		t.Error("Expected lookup by name to be normalized")
+	}
+}
+
+func TestWithoutNameNormalizer(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc, WithName[LoggerEngine]("console")); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := ResolveName[LoggerEngine](ctx, sc, "Console"); err == nil {
+		t.Error("Expected names to be matched exactly by default")
+	}
+}
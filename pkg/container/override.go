@@ -0,0 +1,92 @@
+package container
+
+import (
+	"context"
+	"reflect"
+)
+
+// overrideContextKey is the context key used to store the container created by
+// WithOverrides that started the current resolution.
+type overrideContextKey struct{}
+
+// Override replaces the registrations of a single type in a container created by
+// WithOverrides. Overrides are created via OverrideInstance or OverrideFactory.
+type Override struct {
+	key      reflect.Type
+	register func(*ServiceContainer)
+}
+
+// OverrideInstance creates an override replacing every registration of T with the
+// provided instance.
+//
+// Example:
+//
+//	testSC := container.WithOverrides(OverrideInstance[Clock](fakeClock))
+func OverrideInstance[T any](instance T) Override {
+	return Override{
+		key: typeKey[T](),
+		register: func(sc *ServiceContainer) {
+			// Registrations providing an instance can not fail
+			_ = Register[T](sc, WithInstance(instance))
+		},
+	}
+}
+
+// OverrideFactory creates an override replacing every registration of T with the
+// provided factory, which is called for every resolution of T.
+//
+// Example:
+//
+//	testSC := container.WithOverrides(OverrideFactory[Clock](func(ctx context.Context, sc *ServiceContainer) (Clock, error) {
+//		return NewFakeClock(time.Unix(0, 0)), nil
+//	}))
+func OverrideFactory[T any](factory func(context.Context, *ServiceContainer) (T, error)) Override {
+	return Override{
+		key: typeKey[T](),
+		register: func(sc *ServiceContainer) {
+			// Registrations providing a factory can not fail
+			_ = Register[T](sc, AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+				return factory(ctx, sc)
+			}))
+		},
+	}
+}
+
+// WithOverrides creates a child container (see CreateChild) in which the provided
+// overrides replace the registrations of their types, regardless of the requested
+// name, while all other registrations are inherited from this container. Overrides
+// apply to the whole dependency graph of resolutions started from the child, including
+// services constructed from registrations of this container. This container is never
+// mutated: as with ResolveWith, its already cached singletons are returned as usual,
+// but singletons it constructs on behalf of the child are not cached.
+//
+// This is intended as a seam for integration tests.
+//
+// Example:
+//
+//	testSC := container.WithOverrides(OverrideInstance[Clock](fakeClock))
+//
+//	// Resolves the service with the fake clock injected
+//	service, err := Resolve[*BillingService](ctx, testSC)
+func (sc *ServiceContainer) WithOverrides(overrides ...Override) *ServiceContainer {
+	child := sc.CreateChild()
+	child.overrides = make(map[reflect.Type]bool, len(overrides))
+
+	for _, override := range overrides {
+		override.register(child)
+		child.overrides[override.key] = true
+	}
+
+	return child
+}
+
+// overrideSeam returns the container created by WithOverrides that started the current
+// resolution, if it is not the provided container itself.
+func overrideSeam(ctx context.Context, sc *ServiceContainer) (*ServiceContainer, bool) {
+	seam, ok := ctx.Value(overrideContextKey{}).(*ServiceContainer)
+	if !ok || seam == sc {
+		return nil, false
+	}
+
+	return seam, true
+}
@@ -0,0 +1,82 @@
+package container
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithOverridesInstance(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*AuditService](sc, AsSingleton()))
+	errs.Add(Register[*Agent](sc, AsSingleton()))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	stub := &StubLogger{}
+	testSC := sc.WithOverrides(OverrideInstance[LoggerEngine](stub))
+
+	audit, err := Resolve[*AuditService](ctx, testSC)
+	if err != nil {
+		t.Fatalf("Failed to resolve with overrides: %v", err)
+	}
+
+	if audit.Agent.Logger != stub {
+		t.Error("Expected override to flow down the dependency graph of the parent")
+	}
+
+	if IsInstantiated[*Agent](sc, "") {
+		t.Error("Expected parent not to cache singletons constructed with overrides")
+	}
+
+	agent, err := Resolve[*Agent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger == stub {
+		t.Error("Expected override not to leak into the parent")
+	}
+}
+
+func TestWithOverridesFactory(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*NamedLogger](sc, With[LoggerEngine](), AsSingleton()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	calls := 0
+	testSC := sc.WithOverrides(OverrideFactory[LoggerEngine](func(ctx context.Context, sc *ServiceContainer) (LoggerEngine, error) {
+		calls++
+		return &NamedLogger{name: "fake"}, nil
+	}))
+
+	logger, err := Resolve[LoggerEngine](ctx, testSC)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if named, ok := logger.(*NamedLogger); !ok || named.name != "fake" {
+		t.Errorf("Expected overridden logger instead of cached parent singleton, got %v", logger)
+	}
+
+	if _, err := Resolve[*NamedLogger](ctx, testSC); err != nil {
+		t.Fatalf("Failed to resolve inherited registration: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected override factory to be called once, got %d", calls)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 )
 
 // TagProcessor is an interface for handling fabric tag processing during service creation.
@@ -15,6 +16,13 @@ import (
 // (higher priority first). The first processor that can handle a tag value will
 // be used to process that field.
 //
+// A tag value may chain multiple processors by separating their values with
+// semicolons, e.g. `fabric:"inject; validate"`. Each value is handled by the first
+// processor that can process it, and the processors run in the order their values
+// appear in the tag, regardless of their priority.
+// Every processor after the first can read the value produced by its predecessor
+// via ChainValueFromContext; the value returned by the last processor is injected.
+//
 // Example:
 //
 //	type CustomTagProcessor struct{}
@@ -47,6 +55,15 @@ type TagProcessor interface {
 // processor based on tag values.
 type TagProcessorManager struct {
 	processors []TagProcessor
+
+	// entries contains all registered processors together with their optional key
+	entries []tagProcessorEntry
+}
+
+// tagProcessorEntry stores a registered tag processor with its optional key.
+type tagProcessorEntry struct {
+	key       string
+	processor TagProcessor
 }
 
 // NewTagProcessorManager creates a new TagProcessorManager with an empty
@@ -54,40 +71,170 @@ type TagProcessorManager struct {
 func NewTagProcessorManager() *TagProcessorManager {
 	return &TagProcessorManager{
 		processors: make([]TagProcessor, 0),
+		entries:    make([]tagProcessorEntry, 0),
 	}
 }
 
 // registerProcessor adds one or more tag processors to the manager and
 // sorts them by priority (higher priority first).
 func (tpm *TagProcessorManager) registerProcessor(processor ...TagProcessor) {
-	tpm.processors = append(tpm.processors, processor...)
+	for _, p := range processor {
+		tpm.entries = append(tpm.entries, tagProcessorEntry{processor: p})
+	}
+
+	tpm.sortProcessors()
+}
 
-	sort.Slice(tpm.processors, func(i, j int) bool {
-		return tpm.processors[i].GetPriority() > tpm.processors[j].GetPriority()
+// registerNamedProcessor adds a tag processor with the given key, replacing any
+// processor previously registered with the same key.
+func (tpm *TagProcessorManager) registerNamedProcessor(key string, processor TagProcessor) {
+	for i, entry := range tpm.entries {
+		if entry.key == key {
+			tpm.entries[i].processor = processor
+			tpm.sortProcessors()
+			return
+		}
+	}
+
+	tpm.entries = append(tpm.entries, tagProcessorEntry{key: key, processor: processor})
+	tpm.sortProcessors()
+}
+
+// sortProcessors sorts the registered entries by priority (higher priority first)
+// and rebuilds the ordered processor collection. Processors with equal priority
+// keep their registration order.
+func (tpm *TagProcessorManager) sortProcessors() {
+	sort.SliceStable(tpm.entries, func(i, j int) bool {
+		return tpm.entries[i].processor.GetPriority() > tpm.entries[j].processor.GetPriority()
 	})
+
+	tpm.processors = make([]TagProcessor, 0, len(tpm.entries))
+	for _, entry := range tpm.entries {
+		tpm.processors = append(tpm.processors, entry.processor)
+	}
 }
 
-// processField processes a struct field with the given fabric tag value.
-// It iterates through registered processors in priority order and uses
-// the first processor that can handle the tag value.
-func (tpm *TagProcessorManager) processField(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+// getProcessors returns a copy of the registered processors in priority order.
+func (tpm *TagProcessorManager) getProcessors() []TagProcessor {
+	processors := make([]TagProcessor, len(tpm.processors))
+	copy(processors, tpm.processors)
+
+	return processors
+}
+
+// chainValueContextKey is the context key used to pass the value produced by the
+// previous processor of a tag chain to the next one.
+type chainValueContextKey struct{}
+
+// ChainValueFromContext returns the value produced by the previous processor when a
+// field is handled by a chain of processors, e.g. `fabric:"inject; validate"`.
+// It returns false for the first processor of a chain and for single processor tags.
+//
+// Example:
+//
+//	func (vtp *ValidateTagProcessor) Process(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+//		current, ok := ChainValueFromContext(ctx)
+//		if !ok {
+//			return nil, fmt.Errorf("validate must follow another processor")
+//		}
+//		return current, validate(current)
+//	}
+func ChainValueFromContext(ctx context.Context) (any, bool) {
+	value, ok := ctx.Value(chainValueContextKey{}).(chainValue)
+	return value.value, ok
+}
+
+// chainValue wraps chained values, allowing nil to be passed between processors.
+type chainValue struct {
+	value any
+}
+
+// splitTagChain splits a fabric tag value into the values of its chained processors.
+// Empty values are ignored.
+func splitTagChain(value string) []string {
+	parts := strings.Split(value, ";")
+
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+
+	return result
+}
+
+// processorFor returns the first processor in priority order that can handle the value.
+func (tpm *TagProcessorManager) processorFor(value string) (TagProcessor, bool) {
 	for _, processor := range tpm.processors {
 		if processor.CanProcess(value) {
-			return processor.Process(ctx, sc, field, value)
+			return processor, true
 		}
 	}
 
-	return nil, fmt.Errorf("no processor found for tag value: %s", value)
+	return nil, false
+}
+
+// processField processes a struct field with the given fabric tag value.
+// It uses the first processor in priority order that can handle the tag value.
+// Chained tag values are processed in the order they are written, each by the first
+// processor in priority order that can handle it, threading the value produced by
+// each processor through to the next one.
+func (tpm *TagProcessorManager) processField(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+	chain := splitTagChain(value)
+	if len(chain) <= 1 {
+		processor, ok := tpm.processorFor(value)
+		if !ok {
+			return nil, fmt.Errorf("no processor found for tag value: %s", value)
+		}
+		return processor.Process(ctx, sc, field, value)
+	}
+
+	type step struct {
+		value     string
+		processor TagProcessor
+	}
+
+	steps := make([]step, 0, len(chain))
+	for _, v := range chain {
+		processor, ok := tpm.processorFor(v)
+		if !ok {
+			return nil, fmt.Errorf("no processor found for tag value: %s", v)
+		}
+		steps = append(steps, step{value: v, processor: processor})
+	}
+
+	var current any
+	for i, s := range steps {
+		stepCtx := ctx
+		if i > 0 {
+			stepCtx = context.WithValue(ctx, chainValueContextKey{}, chainValue{value: current})
+		}
+
+		result, err := s.processor.Process(stepCtx, sc, field, s.value)
+		if err != nil {
+			return nil, err
+		}
+		current = result
+	}
+
+	return current, nil
 }
 
 // hasProcessorFor checks if there is a registered processor that can handle
-// the given tag value. This is used during service registration validation.
+// the given tag value, or every value of a chained tag value. This is used
+// during service registration validation.
 func (tpm *TagProcessorManager) hasProcessorFor(value string) bool {
-	for _, processor := range tpm.processors {
-		if processor.CanProcess(value) {
-			return true
+	chain := splitTagChain(value)
+	if len(chain) == 0 {
+		return false
+	}
+
+	for _, v := range chain {
+		if _, ok := tpm.processorFor(v); !ok {
+			return false
 		}
 	}
 
-	return false
+	return true
 }
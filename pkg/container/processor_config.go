@@ -0,0 +1,188 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ConfigTagProcessor is a tag processor that handles fabric:"config:<path>" tags.
+// It lazily resolves the configuration of type C from the container and injects
+// the value found at the dotted path into the tagged field:
+//   - `fabric:"config:Server.Port"` - injects the Port field of the nested Server struct
+//
+// Paths may navigate through nested structs, pointers and maps with string keys.
+// Values are converted to the field type where possible, including conversions
+// between numeric kinds and parsing of strings into numbers and booleans.
+//
+// Example:
+//
+//	type AppConfig struct {
+//		Server struct {
+//			Port int
+//		}
+//	}
+//
+//	type HttpServer struct {
+//		Port int `fabric:"config:Server.Port"`
+//	}
+//
+//	container.AddTagProcessor(NewConfigTagProcessor[*AppConfig]())
+type ConfigTagProcessor[C any] struct{}
+
+// NewConfigTagProcessor creates a new ConfigTagProcessor that resolves its
+// configuration of type C from the container during tag processing.
+func NewConfigTagProcessor[C any]() *ConfigTagProcessor[C] {
+	return &ConfigTagProcessor[C]{}
+}
+
+// GetPriority returns the processing priority for this processor.
+// The config processor has priority 0 (lowest).
+func (ctp *ConfigTagProcessor[C]) GetPriority() int {
+	return 0
+}
+
+// CanProcess returns true if this processor can handle the given tag value.
+// The ConfigTagProcessor handles "config:path", matching the prefix case-insensitively.
+func (ctp *ConfigTagProcessor[C]) CanProcess(value string) bool {
+	return strings.HasPrefix(strings.ToLower(value), "config:")
+}
+
+// Process resolves the configuration from the container, navigates the dotted path
+// defined by the tag value and returns the value converted to the field type.
+func (ctp *ConfigTagProcessor[C]) Process(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+	path := strings.TrimSpace(strings.SplitN(value, ":", 2)[1])
+	if path == "" {
+		return nil, fmt.Errorf("empty config path for field '%s'", field.Name)
+	}
+
+	config, err := sc.resolve(ctx, typeKey[C](), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config for field '%s': %w", field.Name, err)
+	}
+
+	current := reflect.ValueOf(config)
+	for _, segment := range strings.Split(path, ".") {
+		for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+			if current.IsNil() {
+				return nil, fmt.Errorf("config path '%s' contains nil value at '%s'", path, segment)
+			}
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Struct:
+			next := current.FieldByNameFunc(func(name string) bool {
+				return strings.EqualFold(name, segment)
+			})
+			if !next.IsValid() {
+				return nil, fmt.Errorf("unknown config path '%s': field '%s' not found in '%s'", path, segment, current.Type())
+			}
+			current = next
+
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("unknown config path '%s': map '%s' has no string keys", path, current.Type())
+			}
+			next := current.MapIndex(reflect.ValueOf(segment).Convert(current.Type().Key()))
+			if !next.IsValid() {
+				return nil, fmt.Errorf("unknown config path '%s': key '%s' not found", path, segment)
+			}
+			current = next
+
+		default:
+			return nil, fmt.Errorf("unknown config path '%s': can not navigate into '%s' at '%s'", path, current.Type(), segment)
+		}
+	}
+
+	if current.Kind() == reflect.Interface && !current.IsNil() {
+		current = current.Elem()
+	}
+
+	converted, err := convertConfigValue(current, field.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign config path '%s' to field '%s': %w", path, field.Name, err)
+	}
+
+	return converted.Interface(), nil
+}
+
+// convertConfigValue converts the provided value into the target type. Directly
+// assignable values are returned unchanged, numeric kinds are converted between
+// each other and strings are parsed into numbers and booleans.
+func convertConfigValue(value reflect.Value, target reflect.Type) (reflect.Value, error) {
+	if !value.CanInterface() {
+		return reflect.Value{}, fmt.Errorf("value of type '%s' is not exported", value.Type())
+	}
+
+	if value.Type().AssignableTo(target) {
+		return value, nil
+	}
+
+	if isNumericKind(value.Kind()) && isNumericKind(target.Kind()) {
+		return value.Convert(target), nil
+	}
+
+	if value.Kind() == reflect.String {
+		str := value.String()
+		result := reflect.New(target).Elem()
+
+		switch {
+		case target.Kind() == reflect.String:
+			result.SetString(str)
+			return result, nil
+
+		case target.Kind() == reflect.Bool:
+			b, err := strconv.ParseBool(str)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetBool(b)
+			return result, nil
+
+		case target.Kind() >= reflect.Int && target.Kind() <= reflect.Int64:
+			i, err := strconv.ParseInt(str, 10, target.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetInt(i)
+			return result, nil
+
+		case target.Kind() >= reflect.Uint && target.Kind() <= reflect.Uint64:
+			u, err := strconv.ParseUint(str, 10, target.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetUint(u)
+			return result, nil
+
+		case target.Kind() == reflect.Float32 || target.Kind() == reflect.Float64:
+			f, err := strconv.ParseFloat(str, target.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetFloat(f)
+			return result, nil
+		}
+	}
+
+	if value.Type().ConvertibleTo(target) && value.Kind() == target.Kind() {
+		return value.Convert(target), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("can not convert '%s' to '%s'", value.Type(), target)
+}
+
+// isNumericKind reports whether the provided kind is an integer or floating point kind.
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package container
+
+import "testing"
+
+type ServerConfig struct {
+	Host string
+	Port int
+}
+
+type AppConfig struct {
+	Server  *ServerConfig
+	Debug   string
+	Limits  map[string]any
+	Timeout int32
+}
+
+type HttpServer struct {
+	Host    string  `fabric:"config:Server.Host"`
+	Port    int64   `fabric:"config:Server.Port"`
+	Debug   bool    `fabric:"config:Debug"`
+	Rate    float64 `fabric:"config:Limits.rate"`
+	Timeout int     `fabric:"config:timeout"`
+}
+
+type BrokenServer struct {
+	Port int `fabric:"config:Server.Unknown"`
+}
+
+func TestConfigTagProcessor(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	sc.AddTagProcessor(NewConfigTagProcessor[*AppConfig]())
+
+	config := &AppConfig{
+		Server:  &ServerConfig{Host: "localhost", Port: 8080},
+		Debug:   "true",
+		Limits:  map[string]any{"rate": 2.5},
+		Timeout: 30,
+	}
+
+	errs := &Errors{}
+	errs.Add(Register[*AppConfig](sc, WithInstance(config)))
+	errs.Add(Register[*HttpServer](sc))
+	errs.Add(Register[*BrokenServer](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	server, err := Resolve[*HttpServer](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve server: %v", err)
+	}
+
+	if server.Host != "localhost" || server.Port != 8080 || !server.Debug || server.Rate != 2.5 || server.Timeout != 30 {
+		t.Errorf("Config values were not successfully injected: %+v", server)
+	}
+
+	if _, err := Resolve[*BrokenServer](ctx, sc); err == nil {
+		t.Error("Expected resolution with unknown config path to fail")
+	}
+}
@@ -2,8 +2,10 @@ package container
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -45,93 +47,65 @@ func (itp *InjectTagProcessor) CanProcess(value string) bool {
 // resolves the appropriate service from the container.
 func (itp *InjectTagProcessor) Process(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
 	// Parse the tag value to extract the service name
-	serviceName := ""
-	if strings.Contains(value, ":") {
-		parts := strings.SplitN(value, ":", 2)
-		if len(parts) == 2 {
-			serviceName = strings.TrimSpace(parts[1])
-		}
-	}
+	serviceName := parseInjectName(value)
 
-	// Try to resolve by name if specified
-	if serviceName != "" {
-		// Use reflection to call ResolveName with the service name
-		return itp.resolveByName(ctx, sc, field.Type, serviceName)
-	}
-
-	// Fall back to unnamed resolution
-	ok, resolved := sc.ResolveByType(ctx, field.Type)
-	if !ok {
-		return nil, fmt.Errorf("failed to inject type '%s' for field '%s'", field.Type, field.Name)
+	resolved, err := sc.resolve(ctx, field.Type, serviceName)
+	if err != nil {
+		return nil, injectError(sc, field, serviceName, err)
 	}
 
 	return resolved, nil
 }
 
-// resolveByName resolves a service by type and name using the container's internal resolution
-func (itp *InjectTagProcessor) resolveByName(ctx context.Context, sc *ServiceContainer, fieldType reflect.Type, name string) (any, error) {
-	sc.mu.RLock()
-	serviceMaps, exists := sc.services[fieldType]
-	sc.mu.RUnlock()
-
-	if !exists {
-		return nil, fmt.Errorf("no service registered for type '%s'", fieldType)
+// injectError describes the failed resolution of the dependency of the provided field. A
+// failed unnamed injection suggests the available names if the type only has named
+// registrations.
+func injectError(sc *ServiceContainer, field reflect.StructField, name string, err error) error {
+	if name != "" {
+		return fmt.Errorf("failed to create service '%s' with name '%s': %w", field.Type, name, err)
 	}
 
-	service, exists := serviceMaps[name]
-	if !exists {
-		return nil, fmt.Errorf("no service registered for type '%s' with name '%s'", fieldType, name)
+	if candidates := namedCandidates(sc, field.Type); len(candidates) > 0 && errors.Is(err, ErrNotRegistered) {
+		return fmt.Errorf("failed to inject type '%s' for field '%s', found %d named registrations: %s - specify one via inject:name: %w",
+			field.Type, field.Name, len(candidates), strings.Join(candidates, ", "), err)
 	}
 
-	// Handle singleton resolution
-	if service.IsSingleton {
-		sc.mu.RLock()
-		singletonsMaps, exists := sc.singletons[fieldType]
-		sc.mu.RUnlock()
-
-		if exists {
-			if singleton, exists := singletonsMaps[name]; exists {
-				return singleton, nil
-			}
-		}
-	}
+	return fmt.Errorf("failed to inject type '%s' for field '%s': %w", field.Type, field.Name, err)
+}
 
-	// Create new instance using factory
-	if service.Factory == nil {
-		return nil, fmt.Errorf("no factory available for service '%s' with name '%s'", fieldType, name)
+// namedCandidates returns the names of all registrations of the provided type if none of
+// them is unnamed, so a failed unnamed injection can suggest the available names.
+func namedCandidates(sc *ServiceContainer, key reflect.Type) []string {
+	names := sc.registrationNames(key)
+	if slices.Contains(names, "") {
+		return nil
 	}
 
-	instance, err := service.Factory(ctx, sc)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create service '%s' with name '%s': %w", fieldType, name, err)
-	}
+	return names
+}
 
-	// Apply middlewares
-	for _, middleware := range sc.middlewares {
-		instance, err = middleware.Process(ctx, fieldType, instance)
-		if err != nil {
-			return nil, fmt.Errorf("failed to process middleware for service '%s' with name '%s': %w", fieldType, name, err)
+// parseInjectName extracts the service name from an inject tag value.
+// It returns an empty string for unnamed injection ("inject").
+func parseInjectName(value string) string {
+	if strings.Contains(value, ":") {
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) == 2 {
+			return strings.TrimSpace(parts[1])
 		}
 	}
 
-	// Run lifecycle
-	if err := sc.runLifecycle(ctx, instance); err != nil {
-		return nil, fmt.Errorf("failed to run lifecycle for service '%s' with name '%s': %w", fieldType, name, err)
-	}
+	return ""
+}
 
-	// Cache singleton if needed
-	if service.IsSingleton {
-		sc.mu.Lock()
-		singletonsMaps, exists := sc.singletons[fieldType]
-		if exists {
-			singletonsMaps[name] = instance
-		} else {
-			singletonsMaps = make(map[string]any)
-			singletonsMaps[name] = instance
-			sc.singletons[fieldType] = singletonsMaps
+// injectTagValue returns the inject value of a fabric tag value, which may be part
+// of a processor chain such as "inject:name; validate".
+func injectTagValue(value string) (string, bool) {
+	inject := NewInjectTagProcessor()
+	for _, v := range splitTagChain(value) {
+		if inject.CanProcess(v) {
+			return v, true
 		}
-		sc.mu.Unlock()
 	}
 
-	return instance, nil
+	return "", false
 }
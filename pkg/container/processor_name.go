@@ -0,0 +1,50 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// NameTagProcessor is a default tag processor that handles fabric:"name" tags.
+// It injects the name of the registration currently being resolved into string
+// fields, allowing services to know the name they were registered under:
+//   - `fabric:"name"` - injects the registration name, or an empty string for unnamed registrations
+//
+// Example:
+//
+//	type MetricsCollector struct {
+//		Prefix string `fabric:"name"`
+//	}
+//
+//	Register[*MetricsCollector](container, AsNamed("http"))
+type NameTagProcessor struct{}
+
+// NewNameTagProcessor creates a new NameTagProcessor instance.
+// This processor is registered by default when creating a new service container.
+func NewNameTagProcessor() *NameTagProcessor {
+	return &NameTagProcessor{}
+}
+
+// GetPriority returns the processing priority for this processor.
+// The default name processor has priority 0 (lowest).
+func (ntp *NameTagProcessor) GetPriority() int {
+	return 0
+}
+
+// CanProcess returns true if this processor can handle the given tag value.
+// The NameTagProcessor handles "name", matching case-insensitively.
+func (ntp *NameTagProcessor) CanProcess(value string) bool {
+	return strings.EqualFold(value, "name")
+}
+
+// Process returns the name of the registration currently being resolved, as provided
+// by NameFromContext, converted to the string kind of the field.
+func (ntp *NameTagProcessor) Process(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+	if field.Type.Kind() != reflect.String {
+		return nil, fmt.Errorf("fabric tag 'name' requires a string field, got '%s' for field '%s'", field.Type, field.Name)
+	}
+
+	return reflect.ValueOf(NameFromContext(ctx)).Convert(field.Type).Interface(), nil
+}
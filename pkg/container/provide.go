@@ -0,0 +1,72 @@
+package container
+
+// Provider is a fluent builder for a registration of type T, created via Provide. It
+// accumulates registration options and commits them via Register, making multi-interface,
+// named or singleton registrations readable without stacking many options in one call.
+// Every method is equivalent to the corresponding registration option, so a provider
+// produces exactly the same registration as calling Register with the same options.
+type Provider[T any] struct {
+	sc      *ServiceContainer
+	options []RegistrationOption
+}
+
+// Provide starts a fluent registration of type T with the provided container. The
+// registration is only committed once Register is called.
+//
+// Example:
+//
+//	err := Provide[*PostgresDB](container).
+//		As(With[Database](), With[HealthChecker]()).
+//		Named("primary").
+//		Singleton().
+//		Register()
+func Provide[T any](sc *ServiceContainer) *Provider[T] {
+	return &Provider[T]{sc: sc}
+}
+
+// As maps the registration to interfaces, see With and WithName. Since methods can not
+// declare type parameters, the interfaces are provided as registration options.
+func (p *Provider[T]) As(mappings ...RegistrationOption) *Provider[T] {
+	return p.With(mappings...)
+}
+
+// Named registers the concrete type under the provided name, see AsNamed.
+func (p *Provider[T]) Named(name string) *Provider[T] {
+	return p.With(AsNamed(name))
+}
+
+// Singleton registers the service as singleton, see AsSingleton.
+func (p *Provider[T]) Singleton() *Provider[T] {
+	return p.With(AsSingleton())
+}
+
+// Scoped registers the service as scoped, see AsScoped.
+func (p *Provider[T]) Scoped() *Provider[T] {
+	return p.With(AsScoped())
+}
+
+// Transient registers the service as explicitly transient, see AsTransient.
+func (p *Provider[T]) Transient() *Provider[T] {
+	return p.With(AsTransient())
+}
+
+// Factory creates instances using the provided factory, see AsFactory.
+func (p *Provider[T]) Factory(factory RegistrationFactory) *Provider[T] {
+	return p.With(AsFactory(factory))
+}
+
+// Instance registers a pre-created instance, see WithInstance.
+func (p *Provider[T]) Instance(instance any) *Provider[T] {
+	return p.With(WithInstance(instance))
+}
+
+// With appends arbitrary registration options, applied in the order they were added.
+func (p *Provider[T]) With(options ...RegistrationOption) *Provider[T] {
+	p.options = append(p.options, options...)
+	return p
+}
+
+// Register commits the registration with all accumulated options, see Register.
+func (p *Provider[T]) Register() error {
+	return Register[T](p.sc, p.options...)
+}
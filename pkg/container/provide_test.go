@@ -0,0 +1,55 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProvideMatchesRegistrationOptions(t *testing.T) {
+	built := NewServiceContainer()
+	stacked := NewServiceContainer()
+	ctx := t.Context()
+
+	err := Provide[*CryptoLogger](built).
+		As(With[LoggerEngine](), WithName[EncryptEngine]("secure")).
+		Named("primary").
+		Singleton().
+		With(WithMetadata(map[string]string{"format": "json"})).
+		Register()
+	if err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := Register[*CryptoLogger](stacked,
+		With[LoggerEngine](),
+		WithName[EncryptEngine]("secure"),
+		AsNamed("primary"),
+		AsSingleton(),
+		WithMetadata(map[string]string{"format": "json"})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	key := typeKey[*CryptoLogger]()
+	expected := *stacked.services[key]["primary"]
+	actual := *built.services[key]["primary"]
+
+	// Factories are created per registration and can not be compared
+	expected.Factory, actual.Factory = nil, nil
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected builder to produce %+v, got %+v", expected, actual)
+	}
+
+	logger, err := Resolve[LoggerEngine](ctx, built)
+	if err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	encrypt, err := ResolveName[EncryptEngine](ctx, built, "secure")
+	if err != nil {
+		t.Fatalf("Failed to resolve encrypt: %v", err)
+	}
+
+	if logger != encrypt.(LoggerEngine) {
+		t.Error("Expected interfaces to share the singleton")
+	}
+}
@@ -0,0 +1,66 @@
+package container
+
+import (
+	"context"
+	"sync"
+)
+
+// Scope is a child container with its own lifetime. Services registered with
+// AsScoped() are created once per scope and cached within it, while all other
+// services are resolved from the parent container as usual.
+//
+// A scope is closed either explicitly via Close or automatically once the context
+// it was created with is cancelled. Closing a scope cleans up all scoped services
+// implementing LifecycleService in reverse creation order. Services whose factory
+// or initialization failed are never cleaned up.
+type Scope struct {
+	*ServiceContainer
+
+	// stop unregisters the automatic close on context cancellation
+	stop func() bool
+
+	// once ensures the scope is only closed once
+	once sync.Once
+
+	// err contains the result of closing the scope
+	err error
+}
+
+// CreateScope creates a new scope as child of this container. The scope is closed
+// automatically once the provided context is cancelled.
+//
+// Example:
+//
+//	scope := container.CreateScope(r.Context())
+//	defer scope.Close(context.Background())
+//
+//	requestCtx, err := Resolve[*RequestContext](ctx, scope.ServiceContainer)
+func (sc *ServiceContainer) CreateScope(ctx context.Context) *Scope {
+	child := sc.CreateChild()
+	child.scoped = true
+
+	scope := &Scope{
+		ServiceContainer: child,
+	}
+	scope.stop = context.AfterFunc(ctx, func() {
+		scope.close(context.WithoutCancel(ctx))
+	})
+
+	return scope
+}
+
+// Close closes the scope and cleans up all scoped services implementing LifecycleService
+// in reverse creation order. Calling Close more than once returns the result of the first call.
+func (s *Scope) Close(ctx context.Context) error {
+	s.stop()
+	return s.close(ctx)
+}
+
+// close runs the cleanup of the scope exactly once.
+func (s *Scope) close(ctx context.Context) error {
+	s.once.Do(func() {
+		s.err = s.Cleanup(ctx)
+	})
+
+	return s.err
+}
@@ -0,0 +1,126 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type ScopedFirst struct{ order *[]string }
+
+func (sf *ScopedFirst) Init(ctx context.Context) error { return nil }
+
+func (sf *ScopedFirst) Cleanup(ctx context.Context) error {
+	*sf.order = append(*sf.order, "first")
+	return nil
+}
+
+type ScopedSecond struct{ order *[]string }
+
+func (ss *ScopedSecond) Init(ctx context.Context) error { return nil }
+
+func (ss *ScopedSecond) Cleanup(ctx context.Context) error {
+	*ss.order = append(*ss.order, "second")
+	return nil
+}
+
+type ScopedBroken struct{ order *[]string }
+
+func (sb *ScopedBroken) Init(ctx context.Context) error { return errors.New("init failed") }
+
+func (sb *ScopedBroken) Cleanup(ctx context.Context) error {
+	*sb.order = append(*sb.order, "broken")
+	return nil
+}
+
+func registerScoped(t *testing.T, sc *ServiceContainer, order *[]string) {
+	errs := &Errors{}
+	errs.Add(Register[*ScopedFirst](sc, AsScoped(), AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return &ScopedFirst{order: order}, nil
+	})))
+	errs.Add(Register[*ScopedSecond](sc, AsScoped(), AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return &ScopedSecond{order: order}, nil
+	})))
+	errs.Add(Register[*ScopedBroken](sc, AsScoped(), AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+		return &ScopedBroken{order: order}, nil
+	})))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+}
+
+func TestScopeCloseCleanupOrder(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	order := make([]string, 0)
+	registerScoped(t, sc, &order)
+
+	if _, err := Resolve[*ScopedFirst](ctx, sc); err == nil {
+		t.Error("Expected scoped resolution outside of scope to fail")
+	}
+
+	scope := sc.CreateScope(ctx)
+
+	first, err := Resolve[*ScopedFirst](ctx, scope.ServiceContainer)
+	if err != nil {
+		t.Fatalf("Failed to resolve first: %v", err)
+	}
+	if again, _ := Resolve[*ScopedFirst](ctx, scope.ServiceContainer); again != first {
+		t.Error("Expected scoped service to be cached within scope")
+	}
+
+	if _, err := Resolve[*ScopedSecond](ctx, scope.ServiceContainer); err != nil {
+		t.Fatalf("Failed to resolve second: %v", err)
+	}
+	if _, err := Resolve[*ScopedBroken](ctx, scope.ServiceContainer); err == nil {
+		t.Fatal("Expected broken service to fail")
+	}
+
+	other := sc.CreateScope(ctx)
+	if otherFirst, _ := Resolve[*ScopedFirst](ctx, other.ServiceContainer); otherFirst == first {
+		t.Error("Expected scoped services to differ between scopes")
+	}
+
+	if err := scope.Close(ctx); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+	if err := scope.Close(ctx); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "second" || order[1] != "first" {
+		t.Errorf("Expected cleanup order [second first], got %v", order)
+	}
+}
+
+func TestScopeClosedOnContextCancel(t *testing.T) {
+	sc := NewServiceContainer()
+
+	order := make([]string, 0)
+	registerScoped(t, sc, &order)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	scope := sc.CreateScope(ctx)
+
+	if _, err := Resolve[*ScopedFirst](ctx, scope.ServiceContainer); err != nil {
+		t.Fatalf("Failed to resolve first: %v", err)
+	}
+
+	cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- scope.Close(context.Background()) }()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for scope to close")
+	}
+
+	if len(order) != 1 || order[0] != "first" {
+		t.Errorf("Expected scoped service to be cleaned up on cancel, got %v", order)
+	}
+}
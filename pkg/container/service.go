@@ -2,7 +2,10 @@ package container
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
+	"time"
 )
 
 // RegistrationService holds the configuration for a registered service,
@@ -12,14 +15,81 @@ type RegistrationService struct {
 	// Name is the optional name for this service registration, used for named resolution
 	Name string
 
+	// Type is the concrete type this service was registered with
+	Type reflect.Type
+
+	// FabricTags indicates whether instances are created by the fabric tag factory,
+	// resolving their dependencies through fabric struct tags
+	FabricTags bool
+
+	// DisableTagProcessing forces the default zero-value factory, ignoring fabric tags
+	DisableTagProcessing bool
+
+	// DisableGeneratedFactory forces reflective fabric tag injection, ignoring generated factories
+	DisableGeneratedFactory bool
+
 	// IsSingleton indicates whether this service should be created once and cached
 	IsSingleton bool
 
+	// IsScoped indicates whether this service is created once per scope and cached within it
+	IsScoped bool
+
+	// IsTransient indicates whether this service is explicitly transient and owned by the caller,
+	// excluding its instances from container cleanup
+	IsTransient bool
+
+	// CleanupFirst moves the cleanup of this service before all other services
+	CleanupFirst bool
+
+	// CleanupLast moves the cleanup of this service after all other services
+	CleanupLast bool
+
+	// Priority decides which registration wins an unnamed interface mapping claimed by multiple registrations
+	Priority int
+
+	// Prioritized indicates whether a priority was set via WithPriority
+	Prioritized bool
+
+	// CacheFailedInit indicates whether a failed singleton initialization is remembered
+	// and returned on subsequent resolutions instead of being retried
+	CacheFailedInit bool
+
 	// Factory is the function used to create instances of this service
 	Factory func(context.Context, *ServiceContainer) (any, error)
 
 	// Interfaces maps interface types to their associated names for this service
 	Interfaces map[reflect.Type][]string
+
+	// Middlewares contains registration-specific middlewares, executed after the global ones
+	Middlewares []MiddlewareServiceV2
+
+	// Capabilities contains the capabilities that must be enabled for this registration to be active
+	Capabilities []string
+
+	// ConstructTimeout limits the duration of each factory and Init call, 0 if unlimited
+	ConstructTimeout time.Duration
+
+	// MaxConcurrentConstruction limits how many instances are constructed simultaneously, 0 if unlimited
+	MaxConcurrentConstruction int
+
+	// constructions is the semaphore enforcing MaxConcurrentConstruction
+	constructions chan struct{}
+
+	// instance is the pre-created instance provided via WithInstance, nil otherwise
+	instance any
+
+	// internal marks the default registrations of every container, which are resolvable
+	// but excluded from enumerations such as Registrations, Validate and ResolveAllAssignable
+	internal bool
+
+	// CacheKey selects the cache slot of a singleton or scoped instance per resolution, nil for a single slot
+	CacheKey func(context.Context) string
+
+	// Metadata contains arbitrary key/value pairs describing this registration for discovery
+	Metadata map[string]string
+
+	// AfterResolve contains callbacks run once per constructed instance, after it has been fully wired
+	AfterResolve []func(context.Context, any) error
 }
 
 // RegistrationOption is a function type used to configure service registrations.
@@ -30,6 +100,9 @@ type RegistrationOption func(*RegistrationService) error
 // RegistrationFactory is a function type for creating service instances.
 // It receives a context and the service container, and returns the created
 // instance or an error if creation fails.
+//
+// Factories are called without holding the container lock, so they can safely resolve
+// dependencies and register derived services, which are resolvable once registered.
 type RegistrationFactory func(ctx context.Context, sc *ServiceContainer) (any, error)
 
 // defaultRegistrationOptions creates a RegistrationService with default settings.
@@ -41,6 +114,26 @@ func defaultRegistrationOptions() *RegistrationService {
 		IsSingleton: false,
 		Factory:     nil,
 		Interfaces:  make(map[reflect.Type][]string),
+		Middlewares: make([]MiddlewareServiceV2, 0),
+	}
+}
+
+// AsNamed configures the name under which the concrete type itself is registered.
+// This allows the same concrete type to be registered multiple times with different
+// lifetimes or factories, each distinguished by its name and resolved via ResolveName.
+// Without this option, the concrete type is registered under the empty name.
+//
+// Example:
+//
+//	// Shared singleton, resolved via Resolve[*Cache]
+//	Register[*Cache](container, AsSingleton())
+//
+//	// Transient variant, resolved via ResolveName[*Cache](ctx, container, "fresh")
+//	Register[*Cache](container, AsNamed("fresh"))
+func AsNamed(name string) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.Name = name
+		return nil
 	}
 }
 
@@ -58,6 +151,164 @@ func AsSingleton() RegistrationOption {
 	}
 }
 
+// AsTransient explicitly configures a service registration to use transient lifecycle.
+// A new instance is created for every resolution and, unlike implicitly transient
+// registrations, instances implementing LifecycleService are initialized but not
+// registered for container cleanup. The caller owns the instance and is responsible
+// for cleaning it up, e.g. using the release function returned by ResolveWithRelease.
+//
+// This establishes a clear ownership contract: singleton and scoped instances are
+// managed by the container, explicitly transient instances by the caller.
+//
+// Example:
+//
+//	Register[*RequestBuffer](container, AsTransient())
+func AsTransient() RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.IsTransient = true
+		rs.IsSingleton = false
+		rs.IsScoped = false
+		return nil
+	}
+}
+
+// AsScoped configures a service registration to use scoped lifecycle. Scoped services
+// are created once per scope (see CreateScope) and the same instance is returned for all
+// resolutions within that scope. Scoped services implementing LifecycleService are
+// cleaned up when their scope is closed. Resolving a scoped service outside of a scope
+// returns an error.
+//
+// Example:
+//
+//	Register[*RequestContext](container, AsScoped())
+func AsScoped() RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.IsScoped = true
+		return nil
+	}
+}
+
+// WithCapability gates a service registration behind one or more capabilities. The
+// registration is only active if all of its capabilities have been enabled on the
+// container via EnableCapability; otherwise it is treated as not registered and
+// ignored during resolution. Capabilities are evaluated by the container owning the
+// registration when it is resolved, so they can be enabled after registering.
+//
+// Example:
+//
+//	Register[*PrometheusExporter](container, With[MetricsExporter](), WithCapability("metrics"))
+//	container.EnableCapability("metrics")
+func WithCapability(names ...string) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.Capabilities = append(rs.Capabilities, names...)
+		return nil
+	}
+}
+
+// AsCleanupFirst hints that instances of this registration should be cleaned up before
+// all other services during container cleanup, regardless of their initialization
+// order. Services with the same hint are cleaned up in reverse order among each other.
+//
+// Example:
+//
+//	Register[*HttpServer](container, AsSingleton(), AsCleanupFirst())
+func AsCleanupFirst() RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.CleanupFirst = true
+		rs.CleanupLast = false
+		return nil
+	}
+}
+
+// AsCleanupLast hints that instances of this registration should be cleaned up after
+// all other services during container cleanup, regardless of their initialization
+// order. This is useful for services used by the cleanup of others, such as loggers.
+//
+// Example:
+//
+//	Register[*LoggerService](container, AsSingleton(), AsCleanupLast())
+func AsCleanupLast() RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.CleanupLast = true
+		rs.CleanupFirst = false
+		return nil
+	}
+}
+
+// WithPriority sets the priority of a registration for its unnamed interface mappings.
+// If multiple registrations map to the same unnamed interface and at least one of them
+// has a priority, the registration with the highest priority is resolved by Resolve,
+// instead of the most recent registration overwriting the previous one. Registrations
+// without a priority have priority 0, and ties are won by the most recent registration.
+// All competing registrations remain available via ResolveAll.
+//
+// Example:
+//
+//	Register[*MemoryCache](container, With[Cache](), WithPriority(10))
+//	Register[*RedisCache](container, With[Cache](), WithPriority(20))
+//
+//	// Resolves *RedisCache, while ResolveAll[Cache] returns both
+//	cache, err := Resolve[Cache](ctx, container)
+func WithPriority(priority int) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.Priority = priority
+		rs.Prioritized = true
+		return nil
+	}
+}
+
+// CacheFailedInit controls how a singleton registration behaves when the Init method
+// of its LifecycleService fails. By default, failed singletons are not cached and the
+// next resolution retries creation and initialization from scratch, which is useful
+// for transient failures. When enabled, the initialization error is remembered and
+// returned for all subsequent resolutions without calling the factory or Init again,
+// avoiding repeated expensive initialization attempts that are expected to fail.
+//
+// This option has no effect on transient (non-singleton) registrations.
+//
+// Example:
+//
+//	Register[*DatabaseService](container, AsSingleton(), CacheFailedInit(true))
+func CacheFailedInit(enabled bool) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.CacheFailedInit = enabled
+		return nil
+	}
+}
+
+// WithoutTagProcessing disables fabric tag processing for a registration, forcing the
+// default zero-value factory even if the struct carries fabric tags. The tagged fields
+// are left at their zero value and the tags are not validated during registration.
+//
+// This is useful when a struct keeps its tags for documentation or other registrations,
+// but a specific registration should be constructed without automatic injection.
+// Registrations with a custom factory or instance never use tag processing.
+//
+// Example:
+//
+//	Register[*UserService](container, AsNamed("bare"), WithoutTagProcessing())
+func WithoutTagProcessing() RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.DisableTagProcessing = true
+		return nil
+	}
+}
+
+// WithoutGeneratedFactory forces fabric tag injection via reflection, even if a factory
+// generated by the fabric code generator (see RegisterGeneratedFactory) is available for
+// the registered type. This is mainly useful to verify generated factories against the
+// reflective behavior.
+//
+// Example:
+//
+//	Register[*UserService](container, WithoutGeneratedFactory())
+func WithoutGeneratedFactory() RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.DisableGeneratedFactory = true
+		return nil
+	}
+}
+
 // AsFactory configures a service registration to use a custom factory function
 // for creating instances. The factory function receives the current context
 // and service container, allowing for complex initialization logic.
@@ -80,7 +331,9 @@ func AsFactory(factory RegistrationFactory) RegistrationOption {
 
 // WithInstance configures a service registration to use a pre-created instance.
 // The provided instance will always be returned when this service is resolved,
-// effectively making it a singleton with the specific instance.
+// effectively making it a singleton with the specific instance. The lifetime options
+// of the registration are kept, while an instance implementing LifecycleService only
+// has its Init method called once.
 //
 // This is useful for registering configuration objects, pre-configured clients,
 // or other instances that should be shared across the application.
@@ -91,6 +344,7 @@ func AsFactory(factory RegistrationFactory) RegistrationOption {
 //	Register[*Config](container, WithInstance(config))
 func WithInstance(instance any) RegistrationOption {
 	return func(rs *RegistrationService) error {
+		rs.instance = instance
 		rs.Factory = func(ctx context.Context, sc *ServiceContainer) (any, error) {
 			return instance, nil
 		}
@@ -98,6 +352,14 @@ func WithInstance(instance any) RegistrationOption {
 	}
 }
 
+// asInternal marks the registration as a default registration of the container.
+func asInternal() RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.internal = true
+		return nil
+	}
+}
+
 // With registers an interface that this concrete type implements, allowing
 // the service to be resolved by its interface type without a name.
 // This enables dependency abstraction and makes code more testable.
@@ -151,3 +413,207 @@ func WithName[I any](name string) RegistrationOption {
 		return nil
 	}
 }
+
+// WithMiddleware attaches one or more middlewares that only process instances
+// created by this registration. Registration-specific middlewares are executed
+// in the order they are provided, after all global middlewares registered via
+// AddMiddleware have been applied.
+//
+// Example:
+//
+//	Register[*PaymentClient](container,
+//		With[PaymentGateway](),
+//		WithMiddleware(&RetryMiddleware{Attempts: 3}))
+func WithMiddleware(middlewares ...MiddlewareService) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.Middlewares = append(rs.Middlewares, adaptMiddlewares(middlewares)...)
+		return nil
+	}
+}
+
+// WithConstructTimeout limits the duration of every factory and lifecycle Init call of
+// this registration. Each call receives a context derived from the resolution context
+// that is cancelled once the timeout has elapsed, so factories making blocking network
+// calls can honor it. If a call exceeds the timeout, construction is aborted with an
+// error wrapping ErrConstructTimeout, even if the call eventually succeeded; an instance
+// initialized too late is cleaned up immediately. Unlike a timeout on the resolution
+// context, this only bounds the construction of this registration and its dependencies
+// resolved within the factory.
+//
+// Since the timeout relies on the factory and Init honoring their context, calls that
+// ignore it still block the resolution until they return.
+//
+// Example:
+//
+//	Register[*RemoteConfig](container, AsSingleton(), WithConstructTimeout(5*time.Second))
+func WithConstructTimeout(timeout time.Duration) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		if timeout <= 0 {
+			return fmt.Errorf("construction timeout must be positive, got %s", timeout)
+		}
+
+		rs.ConstructTimeout = timeout
+		return nil
+	}
+}
+
+// constructContext derives the context for a single factory or Init call, applying the
+// construction timeout of the registration if configured.
+func (rs *RegistrationService) constructContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if rs.ConstructTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeoutCause(ctx, rs.ConstructTimeout, ErrConstructTimeout)
+}
+
+// checkConstructTimeout returns an error wrapping ErrConstructTimeout and the error of the
+// call if the provided construction context exceeded the timeout of the registration.
+// Otherwise, the error of the call is returned unchanged.
+func (rs *RegistrationService) checkConstructTimeout(ctx context.Context, call string, err error) error {
+	if rs.ConstructTimeout <= 0 || !errors.Is(context.Cause(ctx), ErrConstructTimeout) {
+		return err
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s of '%s' exceeded timeout of %s: %w: %w", call, rs.Type, rs.ConstructTimeout, ErrConstructTimeout, err)
+	}
+
+	return fmt.Errorf("%s of '%s' exceeded timeout of %s: %w", call, rs.Type, rs.ConstructTimeout, ErrConstructTimeout)
+}
+
+// WithMaxConcurrentConstruction limits the number of instances of this registration that
+// are constructed simultaneously to n. Excess resolutions block until a construction has
+// finished, or fail once their context is done. A construction spans the factory, the
+// middlewares and the lifecycle initialization, none of which holds the container lock, so
+// resolutions of other registrations never wait for it. Resolutions of a singleton waiting for its
+// construction return the cached instance once it is available instead of constructing
+// another one. This prevents a thundering herd of resource-heavy constructions, such as
+// spawning processes, under concurrent load.
+//
+// Example:
+//
+//	Register[*WorkerProcess](container, WithMaxConcurrentConstruction(2))
+func WithMaxConcurrentConstruction(n int) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		if n < 1 {
+			return fmt.Errorf("maximum of concurrent constructions must be at least 1, got %d", n)
+		}
+
+		rs.MaxConcurrentConstruction = n
+		rs.constructions = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// acquireConstruction waits until the registration may construct another instance and
+// returns a function releasing the slot. Registrations without a limit return immediately.
+func (rs *RegistrationService) acquireConstruction(ctx context.Context) (func(), error) {
+	if rs.constructions == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case rs.constructions <- struct{}{}:
+		return func() { <-rs.constructions }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// WithCacheKey caches one instance per distinct key returned by fn instead of a single
+// instance, turning a singleton or scoped registration into a multiton. The key is computed
+// from the resolution context, so the factory constructs a new instance the first time a key
+// is seen and every later resolution with the same key returns the cached instance. The
+// option has no effect on transient registrations.
+//
+// Every distinct key keeps its instance cached until the container is reset or the module
+// of the registration is removed, so the keys should stem from a small, bounded set such as
+// tenants or regions; keys derived from request IDs or user input grow the cache without limit.
+//
+// Example:
+//
+//	Register[*TenantDB](container, AsSingleton(), WithCacheKey(func(ctx context.Context) string {
+//		return TenantFromContext(ctx)
+//	}))
+func WithCacheKey(fn func(ctx context.Context) string) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		if fn == nil {
+			return fmt.Errorf("cache key function must not be nil")
+		}
+
+		rs.CacheKey = fn
+		return nil
+	}
+}
+
+// WithMetadata attaches key/value metadata to a registration, allowing consumers to
+// discover services by the properties they advertise via FindByMetadata. Applying the
+// option multiple times merges the metadata, with later values overwriting earlier ones.
+//
+// Example:
+//
+//	Register[*JsonExporter](container, With[Exporter](), WithMetadata(map[string]string{"format": "json"}))
+//
+//	// Later discover all JSON exporters:
+//	types := container.FindByMetadata("format", "json")
+func WithMetadata(metadata map[string]string) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		if rs.Metadata == nil {
+			rs.Metadata = make(map[string]string, len(metadata))
+		}
+		for key, value := range metadata {
+			rs.Metadata[key] = value
+		}
+		return nil
+	}
+}
+
+// AfterResolve registers a callback receiving every instance constructed by this
+// registration, for final wiring that does not fit into a factory or Init, such as
+// subscribing the service to an event bus. Callbacks run once per constructed instance,
+// after middlewares and lifecycle initialization, and after all fields marked for late
+// injection have been populated. Instances returned from the cache do not run them again.
+//
+// Callbacks run without holding the container lock, so they can safely resolve other
+// services. If a callback fails, the resolution fails and a cached instance is evicted,
+// so the next resolution constructs a new instance.
+//
+// Example:
+//
+//	Register[*AuditLog](container, AsSingleton(),
+//		AfterResolve(func(ctx context.Context, log *AuditLog) error {
+//			bus, err := Resolve[*EventBus](ctx, container)
+//			if err != nil {
+//				return err
+//			}
+//			return bus.Subscribe(log)
+//		}))
+func AfterResolve[T any](fn func(context.Context, T) error) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.AfterResolve = append(rs.AfterResolve, func(ctx context.Context, instance any) error {
+			typed, ok := instance.(T)
+			if !ok {
+				return fmt.Errorf("resolved value of type '%T' is not assignable to '%s'", instance, typeKey[T]())
+			}
+
+			return fn(ctx, typed)
+		})
+		return nil
+	}
+}
+
+// WithMiddlewareV2 attaches one or more middlewares receiving information about the
+// resolution, including whether the instance was returned from the cache, that only
+// process instances of this registration. They are executed in the order they are
+// provided, interleaved with middlewares attached via WithMiddleware.
+//
+// Example:
+//
+//	Register[*PaymentClient](container, AsSingleton(), WithMiddlewareV2(&ConstructionLogger{}))
+func WithMiddlewareV2(middlewares ...MiddlewareServiceV2) RegistrationOption {
+	return func(rs *RegistrationService) error {
+		rs.Middlewares = append(rs.Middlewares, middlewares...)
+		return nil
+	}
+}
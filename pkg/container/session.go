@@ -0,0 +1,197 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// sessionContextKey is the context key used to store the active resolution session.
+type sessionContextKey struct{}
+
+// lateInjection describes a struct field whose injection has been deferred until
+// all services of the current resolution have been constructed.
+type lateInjection struct {
+	field reflect.StructField
+	value reflect.Value
+	tag   string
+	flags tagFlags
+}
+
+// pendingLifecycle is an initialized instance waiting to be registered for cleanup by
+// the container that constructed it.
+type pendingLifecycle struct {
+	sc    *ServiceContainer
+	entry lifecycleEntry
+}
+
+// pendingCallbacks is a constructed instance waiting for the AfterResolve callbacks of
+// its registration.
+type pendingCallbacks struct {
+	sc       *ServiceContainer
+	key      reflect.Type
+	name     string
+	slot     cacheSlot
+	instance any
+	cached   bool
+}
+
+// resolutionSession tracks state shared by all nested resolutions triggered by a
+// single top-level resolution, such as fields marked for late injection,
+// initialized instances that are not cached and pending AfterResolve callbacks.
+type resolutionSession struct {
+	late      []lateInjection
+	pending   []pendingLifecycle
+	callbacks []pendingCallbacks
+}
+
+// sessionFromContext returns the resolution session stored in the provided context.
+func sessionFromContext(ctx context.Context) (*resolutionSession, bool) {
+	session, ok := ctx.Value(sessionContextKey{}).(*resolutionSession)
+	return session, ok
+}
+
+// withSession runs the provided function within a resolution session. If the context
+// already carries a session, the function simply joins it. Otherwise, a new session is
+// started and all late injections collected while running the function are completed
+// once it returns successfully.
+//
+// Initialized instances that are not cached only enter the cleanup list of their container
+// once the whole session has succeeded. If the session fails, nobody owns them anymore,
+// so they are cleaned up immediately instead.
+func (sc *ServiceContainer) withSession(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := sessionFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	session := &resolutionSession{}
+	ctx = context.WithValue(ctx, sessionContextKey{}, session)
+
+	err := fn(ctx)
+	if err == nil {
+		err = session.complete(ctx, sc)
+	}
+
+	if err != nil {
+		return session.discard(ctx, err)
+	}
+
+	session.commit()
+	return nil
+}
+
+// deferInjection registers a field for late injection within this session.
+func (rs *resolutionSession) deferInjection(field reflect.StructField, value reflect.Value, tag string, flags tagFlags) {
+	rs.late = append(rs.late, lateInjection{
+		field: field,
+		value: value,
+		tag:   tag,
+		flags: flags,
+	})
+}
+
+// deferLifecycle registers an initialized instance to be added to the cleanup list of
+// the provided container once the session has succeeded.
+func (rs *resolutionSession) deferLifecycle(sc *ServiceContainer, entry lifecycleEntry) {
+	rs.pending = append(rs.pending, pendingLifecycle{sc: sc, entry: entry})
+}
+
+// deferCallbacks registers a constructed instance to run the AfterResolve callbacks of
+// its registration once all late injections of this session have been completed.
+func (rs *resolutionSession) deferCallbacks(sc *ServiceContainer, key reflect.Type, name string, slot cacheSlot, instance any, cached bool) {
+	rs.callbacks = append(rs.callbacks, pendingCallbacks{
+		sc:       sc,
+		key:      key,
+		name:     name,
+		slot:     slot,
+		instance: instance,
+		cached:   cached,
+	})
+}
+
+// isPending reports whether the provided lifecycle instance has been initialized within
+// this session and is waiting to be registered for cleanup.
+func (rs *resolutionSession) isPending(lifecycle LifecycleService) bool {
+	if !reflect.TypeOf(lifecycle).Comparable() {
+		return false
+	}
+
+	for _, pending := range rs.pending {
+		if pending.entry.service == lifecycle {
+			return true
+		}
+	}
+
+	return false
+}
+
+// commit adds all pending instances to the cleanup list of their containers.
+func (rs *resolutionSession) commit() {
+	for _, pending := range rs.pending {
+		pending.sc.mu.Lock()
+		pending.sc.addLifecycle(pending.entry)
+		pending.sc.mu.Unlock()
+	}
+}
+
+// discard cleans up all pending instances in reverse initialization order after the
+// session failed with the provided error. Cleanup errors are returned along with it.
+func (rs *resolutionSession) discard(ctx context.Context, err error) error {
+	errs := &Errors{}
+	errs.Add(err)
+
+	for i := len(rs.pending) - 1; i >= 0; i-- {
+		lifecycle := rs.pending[i].entry.service
+		if cleanupErr := lifecycle.Cleanup(ctx); cleanupErr != nil {
+			errs.Add(fmt.Errorf("failed to cleanup '%T' after failed resolution: %w", lifecycle, cleanupErr))
+		}
+	}
+
+	if errs.Len() == 1 {
+		return err
+	}
+
+	return errs.Errors()
+}
+
+// complete populates all fields marked for late injection and runs all pending
+// AfterResolve callbacks afterwards, so they receive fully wired instances. Both may
+// construct further services with late fields or callbacks, so they are processed
+// until none are left.
+func (rs *resolutionSession) complete(ctx context.Context, sc *ServiceContainer) error {
+	for len(rs.late) > 0 || len(rs.callbacks) > 0 {
+		if len(rs.late) > 0 {
+			late := rs.late[0]
+			rs.late = rs.late[1:]
+
+			if err := injectField(ctx, sc, late.field, late.value, late.tag, late.flags); err != nil {
+				return fmt.Errorf("failed to complete late injection: %w", err)
+			}
+			continue
+		}
+
+		pending := rs.callbacks[0]
+		rs.callbacks = rs.callbacks[1:]
+
+		if err := pending.run(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// run calls the AfterResolve callbacks for the pending instance in the order they were
+// registered. If a callback fails, a cached instance is evicted from its container.
+func (pc pendingCallbacks) run(ctx context.Context) error {
+	for _, fn := range pc.slot.service.AfterResolve {
+		if err := fn(ctx, pc.instance); err != nil {
+			if pc.cached {
+				pc.sc.evict(pc.key, pc.name, pc.slot)
+			}
+			return fmt.Errorf("after resolve callback for '%s' with name '%s' failed: %w", pc.key, pc.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package container
+
+import "testing"
+
+type CycleParent struct {
+	Child *CycleChild `fabric:"inject"`
+}
+
+type CycleChild struct {
+	Parent *CycleParent `fabric:"inject,late"`
+}
+
+func TestLateInjectionResolvesCycle(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*CycleParent](sc, AsSingleton()))
+	errs.Add(Register[*CycleChild](sc, AsSingleton()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if err := sc.Validate(); err != nil {
+		t.Fatalf("Expected validation to succeed, got %v", err)
+	}
+
+	parent, err := Resolve[*CycleParent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve parent: %v", err)
+	}
+
+	if parent.Child == nil {
+		t.Fatal("Child was not successfully injected")
+	}
+
+	if parent.Child.Parent != parent {
+		t.Error("Parent was not successfully late injected into child")
+	}
+}
@@ -2,15 +2,144 @@ package container
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 )
 
-func hasFabricTags[T any]() bool {
-	var zero T
-	t := reflect.TypeOf(zero)
+// tagFlags contains the flags appended to a fabric tag value.
+type tagFlags struct {
+	// late defers the injection until all services of the current resolution are constructed
+	late bool
+
+	// optional leaves the field at its zero value if the dependency is not registered
+	optional bool
+
+	// condition names the registered condition that must be met for the field to be injected
+	condition string
+}
+
+// parseFabricTag splits a fabric tag into the value passed to the tag processors and
+// its flags. Flags are appended to the value separated by commas:
+//   - `fabric:"inject,late"` - defers injection until all services of the current
+//     resolution have been constructed, allowing circular dependencies to be resolved
+//   - `fabric:"inject,optional"` - leaves the field nil if the dependency is not registered
+//   - `fabric:"inject,if=name"` - only injects the field if the condition registered
+//     via RegisterCondition with the name is met, leaving it at its zero value otherwise
+func parseFabricTag(tag string) (string, tagFlags) {
+	parts := strings.Split(tag, ",")
+
+	flags := tagFlags{}
+	for _, flag := range parts[1:] {
+		flag = strings.TrimSpace(flag)
+		switch strings.ToLower(flag) {
+		case "late":
+			flags.late = true
+		case "optional":
+			flags.optional = true
+		default:
+			if len(flag) > 3 && strings.EqualFold(flag[:3], "if=") {
+				flags.condition = strings.TrimSpace(flag[3:])
+			}
+		}
+	}
+
+	return strings.TrimSpace(parts[0]), flags
+}
+
+// fieldChainError describes a failed fabric tag injection together with the chain of
+// fields leading from the constructed type to the failing dependency. Every level of a
+// nested injection prepends its field to the chain, so the error reads like
+// "failed to construct *A -> field B (*BService) -> field C (Cache): ..." instead of
+// repeating the context of every level.
+type fieldChainError struct {
+	// constructed is the outermost type being constructed, nil if unknown
+	constructed reflect.Type
+
+	// fields describes the fields of the chain, starting at the outermost field
+	fields []string
+
+	// cause is the error of the innermost field
+	cause error
+
+	// err is the error returned while injecting the outermost field, wrapping all levels
+	err error
+}
+
+func (e *fieldChainError) Error() string {
+	chain := strings.Join(e.fields, " -> ")
+	if e.constructed != nil {
+		return fmt.Sprintf("failed to construct %s -> %s: %v", e.constructed, chain, e.cause)
+	}
+
+	return fmt.Sprintf("failed to inject %s: %v", chain, e.cause)
+}
+
+func (e *fieldChainError) Unwrap() error {
+	return e.err
+}
+
+// wrapFieldError prepends the provided field to the chain of the fieldChainError
+// contained in err, or starts a new chain if the field is the failing dependency.
+func wrapFieldError(field reflect.StructField, err error) error {
+	link := fmt.Sprintf("field %s (%s)", field.Name, field.Type)
+
+	var nested *fieldChainError
+	if errors.As(err, &nested) {
+		return &fieldChainError{
+			fields: append([]string{link}, nested.fields...),
+			cause:  nested.cause,
+			err:    err,
+		}
+	}
+
+	return &fieldChainError{
+		fields: []string{link},
+		cause:  err,
+		err:    err,
+	}
+}
+
+// withConstructedType names the type whose construction failed due to the field chain
+// returned by injectFabricTags or InjectField. Other errors are returned unchanged.
+func withConstructedType(t reflect.Type, err error) error {
+	chain, ok := err.(*fieldChainError)
+	if !ok {
+		return err
+	}
+
+	named := *chain
+	named.constructed = t
+	return &named
+}
+
+// fieldPlan describes a struct field carrying a fabric tag, with its tag already parsed.
+type fieldPlan struct {
+	// index is the index of the field within its struct
+	index int
+
+	// field is the struct field carrying the tag
+	field reflect.StructField
+
+	// tag is the tag value passed to the tag processors, empty if the tag only consists of flags
+	tag string
+
+	// flags contains the flags appended to the tag value
+	flags tagFlags
+}
+
+// fieldPlans caches the fabric field plans of struct types, keyed by reflect.Type.
+var fieldPlans sync.Map
+
+// fabricFields returns the plan of all fields of the provided type carrying a fabric tag.
+// Pointers are dereferenced, and types other than structs have no fabric fields. The plan
+// is computed once per type and shared between registration, validation and injection,
+// so it must not be modified.
+func fabricFields(t reflect.Type) []fieldPlan {
 	if t == nil {
-		return false
+		return nil
 	}
 
 	if t.Kind() == reflect.Ptr {
@@ -18,23 +147,37 @@ func hasFabricTags[T any]() bool {
 	}
 
 	if t.Kind() != reflect.Struct {
-		return false
+		return nil
 	}
 
+	if cached, ok := fieldPlans.Load(t); ok {
+		return cached.([]fieldPlan)
+	}
+
+	plans := make([]fieldPlan, 0)
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
-		if tag := field.Tag.Get("fabric"); tag == "inject" {
-			return true
+		if raw := field.Tag.Get("fabric"); raw != "" {
+			tag, flags := parseFabricTag(raw)
+			plans = append(plans, fieldPlan{
+				index: i,
+				field: field,
+				tag:   tag,
+				flags: flags,
+			})
 		}
 	}
 
-	return false
+	cached, _ := fieldPlans.LoadOrStore(t, plans)
+	return cached.([]fieldPlan)
 }
 
-func validateFabricTags[T any](sc *ServiceContainer) (bool, error) {
-	var zero T
-	t := reflect.TypeOf(zero)
+func hasFabricTags[T any]() bool {
+	return len(fabricFields(typeKey[T]())) > 0
+}
 
+func validateFabricTags[T any](sc *ServiceContainer) (bool, error) {
+	t := typeKey[T]()
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
@@ -43,16 +186,18 @@ func validateFabricTags[T any](sc *ServiceContainer) (bool, error) {
 		return false, nil
 	}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		if tag := field.Tag.Get("fabric"); tag != "" {
-			if !sc.tagProcessor.hasProcessorFor(tag) {
-				return false, fmt.Errorf("no processor registered for fabric tag '%s' on field '%s'", tag, field.Name)
-			}
+	return true, sc.validateFieldPlans(fabricFields(t))
+}
+
+// validateFieldPlans ensures that a tag processor is registered for every planned field.
+func (sc *ServiceContainer) validateFieldPlans(plans []fieldPlan) error {
+	for _, plan := range plans {
+		if plan.tag != "" && !sc.tagProcessor.hasProcessorFor(plan.tag) {
+			return fmt.Errorf("no processor registered for fabric tag '%s' on field '%s'", plan.tag, plan.field.Name)
 		}
 	}
 
-	return true, nil
+	return nil
 }
 
 func createFabricTagFactory[T any]() RegistrationFactory {
@@ -72,30 +217,82 @@ func createFabricTagFactory[T any]() RegistrationFactory {
 		}
 
 		val := reflect.New(t)
-		structVal := val.Elem()
+		if err := injectFabricTags(ctx, sc, val.Elem()); err != nil {
+			return zero, withConstructedType(typeKey[T](), err)
+		}
 
-		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-			fieldVal := structVal.Field(i)
+		v := val.Interface()
+		return v, nil
+	}
+}
 
-			if !fieldVal.CanSet() {
-				continue
-			}
+// injectFabricTags iterates the fields of the provided struct value and populates
+// every settable field carrying a fabric tag using the container's tag processors.
+// Fields marked as late are deferred to the active resolution session, if any.
+// Fields without a fabric tag and unexported fields are never read or written, which
+// allows callers to populate them manually before or after the injection.
+func injectFabricTags(ctx context.Context, sc *ServiceContainer, structVal reflect.Value) error {
+	for _, plan := range fabricFields(structVal.Type()) {
+		fieldVal := structVal.Field(plan.index)
 
-			tag := field.Tag.Get("fabric")
-			if tag != "" {
-				resolved, err := sc.tagProcessor.processField(ctx, sc, field, tag)
-				if err != nil {
-					return zero, fmt.Errorf("failed to process fabric tag for field '%s': %w", field.Name, err)
-				}
+		if !fieldVal.CanSet() || plan.tag == "" {
+			continue
+		}
+
+		if session, ok := sessionFromContext(ctx); ok && plan.flags.late {
+			session.deferInjection(plan.field, fieldVal, plan.tag, plan.flags)
+			continue
+		}
+
+		if err := injectField(ctx, sc, plan.field, fieldVal, plan.tag, plan.flags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-				if resolved != nil {
-					fieldVal.Set(reflect.ValueOf(resolved))
-				}
+// injectField resolves the value for a single fabric-tagged field and assigns it.
+// Optional fields are left at their zero value if the dependency is not registered,
+// conditional fields if their condition is not met.
+// An error is returned if the processed value is not assignable to the field.
+func injectField(ctx context.Context, sc *ServiceContainer, field reflect.StructField, fieldVal reflect.Value, tag string, flags tagFlags) error {
+	if flags.condition != "" {
+		met, err := sc.conditionMet(ctx, flags.condition)
+		if err != nil {
+			return wrapFieldError(field, err)
+		}
+		if !met {
+			return nil
+		}
+	}
+
+	resolved, err := sc.tagProcessor.processField(ctx, sc, field, tag)
+	if err != nil {
+		// Only skip optional fields if the field's own dependency is missing,
+		// not if one of its transitive dependencies is missing
+		if flags.optional && errors.Is(err, ErrNotRegistered) {
+			name := parseInjectName(tag)
+			if value, ok := injectTagValue(tag); ok {
+				name = parseInjectName(value)
+			}
+			if !sc.isRegistered(field.Type, name) {
+				return nil
 			}
 		}
+		return wrapFieldError(field, err)
+	}
 
-		v := val.Interface()
-		return v, nil
+	if isNil(resolved) {
+		return nil
+	}
+
+	// Guard against processors returning values that can not be assigned to the field
+	value := reflect.ValueOf(resolved)
+	if !value.Type().AssignableTo(field.Type) {
+		return wrapFieldError(field, fmt.Errorf("value of type '%s' is not assignable to '%s'", value.Type(), field.Type))
 	}
+
+	fieldVal.Set(value)
+	return nil
 }
@@ -2,7 +2,10 @@ package container
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -64,3 +67,456 @@ func TestFabricTagsInjection(t *testing.T) {
 		t.Error("Encrypt was not successfully injected")
 	}
 }
+
+type PriorityTagProcessor struct{}
+
+func (ptp *PriorityTagProcessor) GetPriority() int { return 100 }
+
+func (ptp *PriorityTagProcessor) CanProcess(value string) bool { return value == "priority" }
+
+func (ptp *PriorityTagProcessor) Process(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+	return nil, nil
+}
+
+func TestTagProcessorsPriorityOrder(t *testing.T) {
+	sc := NewServiceContainer()
+	sc.AddTagProcessor(&PriorityTagProcessor{})
+
+	processors := sc.TagProcessors()
+	if len(processors) != 3 {
+		t.Fatalf("Expected 3 tag processors, got %d", len(processors))
+	}
+
+	if _, ok := processors[0].(*PriorityTagProcessor); !ok {
+		t.Errorf("Expected custom processor first, got %T", processors[0])
+	}
+	if _, ok := processors[1].(*InjectTagProcessor); !ok {
+		t.Errorf("Expected inject processor second, got %T", processors[1])
+	}
+
+	processors[0] = nil
+	if sc.TagProcessors()[0] == nil {
+		t.Error("Expected returned processors to be a copy")
+	}
+}
+
+func TestNamedTagProcessorReplacement(t *testing.T) {
+	sc := NewServiceContainer()
+
+	sc.AddNamedTagProcessor("priority", &PriorityTagProcessor{})
+	sc.AddNamedTagProcessor("priority", &PriorityTagProcessor{})
+
+	if len(sc.TagProcessors()) != 3 {
+		t.Errorf("Expected named tag processor to be replaced, got %d processors", len(sc.TagProcessors()))
+	}
+}
+
+type OptionalAgent struct {
+	Logger *LoggerService `fabric:"inject,optional"`
+	Cache  *CacheService  `fabric:"inject,optional"`
+}
+
+type RequiredAgent struct {
+	Cache *CacheService `fabric:"inject"`
+}
+
+func TestOptionalPointerInjection(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*OptionalAgent](sc))
+	errs.Add(Register[*RequiredAgent](sc))
+	errs.Add(Register[*LoggerService](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	agent, err := Resolve[*OptionalAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent with optional dependency: %v", err)
+	}
+
+	if agent.Logger == nil {
+		t.Error("Logger was not successfully injected")
+	}
+	if agent.Cache != nil {
+		t.Error("Expected missing optional dependency to be left nil")
+	}
+
+	if _, err := Resolve[*RequiredAgent](ctx, sc); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Expected ErrNotRegistered for missing required dependency, got %v", err)
+	}
+}
+
+func TestWithoutTagProcessing(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*Agent](sc, WithoutTagProcessing()); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	agent, err := Resolve[*Agent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger != nil || agent.Encrypt != nil {
+		t.Error("Expected fabric tags to be ignored")
+	}
+}
+
+type NamedInjectionAgent struct {
+	Logger LoggerEngine `fabric:"inject:file"`
+}
+
+func TestFabricTagsNamedInjection(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedInjectionAgent](sc))
+	errs.Add(Register[*LoggerService](sc, WithName[LoggerEngine]("console")))
+	errs.Add(Register[*NamedLogger](sc, WithName[LoggerEngine]("file"), namedLoggerFactory("file")))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	agent, err := Resolve[*NamedInjectionAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if logger, ok := agent.Logger.(*NamedLogger); !ok || logger.name != "file" {
+		t.Errorf("Expected the logger named 'file' to be injected, got '%T'", agent.Logger)
+	}
+}
+
+type RequireTagProcessor struct{}
+
+func (rtp *RequireTagProcessor) GetPriority() int { return -10 }
+
+func (rtp *RequireTagProcessor) CanProcess(value string) bool { return value == "require" }
+
+func (rtp *RequireTagProcessor) Process(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+	current, ok := ChainValueFromContext(ctx)
+	if !ok {
+		return nil, errors.New("require must follow another processor")
+	}
+	if _, ok := current.(*LoggerService); !ok {
+		return nil, fmt.Errorf("field '%s' requires a logger, got %T", field.Name, current)
+	}
+	return current, nil
+}
+
+type ChainedAgent struct {
+	Logger *LoggerService `fabric:"inject; require"`
+}
+
+func TestChainedTagProcessors(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	sc.AddTagProcessor(&RequireTagProcessor{})
+
+	logger := &LoggerService{}
+
+	errs := &Errors{}
+	errs.Add(Register[*ChainedAgent](sc))
+	errs.Add(Register[*LoggerService](sc, WithInstance(logger)))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	agent, err := Resolve[*ChainedAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger != logger {
+		t.Error("Logger was not successfully injected through the chain")
+	}
+}
+
+type ValidateTagProcessor struct {
+	validated *any
+}
+
+// GetPriority is higher than the priority of the inject processor, which must not affect the chain order
+func (vtp *ValidateTagProcessor) GetPriority() int { return 10 }
+
+func (vtp *ValidateTagProcessor) CanProcess(value string) bool { return value == "validate" }
+
+func (vtp *ValidateTagProcessor) Process(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+	current, ok := ChainValueFromContext(ctx)
+	if !ok || current == nil {
+		return nil, fmt.Errorf("field '%s' must be injected before it is validated", field.Name)
+	}
+	*vtp.validated = current
+	return current, nil
+}
+
+type ValidatedAgent struct {
+	Logger LoggerEngine `fabric:"inject; validate"`
+}
+
+func TestChainedTagProcessorsRunInWrittenOrder(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	var validated any
+	sc.AddTagProcessor(&ValidateTagProcessor{validated: &validated})
+
+	errs := &Errors{}
+	errs.Add(Register[*ValidatedAgent](sc))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	agent, err := Resolve[*ValidatedAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger == nil || validated != any(agent.Logger) {
+		t.Error("Expected validator to receive the injected logger")
+	}
+}
+
+func TestChainedTagWithoutProcessor(t *testing.T) {
+	sc := NewServiceContainer()
+
+	if err := Register[*ChainedAgent](sc); err == nil {
+		t.Error("Expected registration to fail without a processor for every chained value")
+	}
+}
+
+type MixedAgent struct {
+	Logger   LoggerEngine `fabric:"inject"`
+	Fallback LoggerEngine
+	Encrypt  EncryptEngine
+}
+
+func TestUntaggedFieldsUntouched(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*MixedAgent](sc))
+	errs.Add(Register[*LoggerService](sc, With[LoggerEngine]()))
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	agent, err := Resolve[*MixedAgent](ctx, sc)
+	if err != nil {
+		t.Fatalf("Failed to resolve agent: %v", err)
+	}
+
+	if agent.Logger == nil {
+		t.Error("Logger was not successfully injected")
+	}
+
+	if agent.Fallback != nil || agent.Encrypt != nil {
+		t.Error("Expected untagged interface fields to remain nil")
+	}
+
+	fallback := &StubLogger{}
+	manual := &MixedAgent{Fallback: fallback}
+	if err := sc.Inject(ctx, manual); err != nil {
+		t.Fatalf("Failed to inject agent: %v", err)
+	}
+
+	if manual.Logger == nil {
+		t.Error("Logger was not successfully injected")
+	}
+
+	if manual.Fallback != fallback || manual.Encrypt != nil {
+		t.Error("Expected untagged fields to be left untouched by injection")
+	}
+}
+
+type NamedCollector struct {
+	Prefix string         `fabric:"name"`
+	Logger *LoggerService `fabric:"inject"`
+}
+
+func TestNameTagInjection(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*NamedCollector](sc))
+	errs.Add(Register[*NamedCollector](sc, AsNamed("http")))
+	errs.Add(Register[*NamedCollector](sc, AsNamed("grpc")))
+	errs.Add(Register[*LoggerService](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	for _, name := range []string{"", "http", "grpc"} {
+		collector, err := ResolveName[*NamedCollector](ctx, sc, name)
+		if err != nil {
+			t.Fatalf("Failed to resolve collector: %v", err)
+		}
+
+		if collector.Prefix != name {
+			t.Errorf("Expected collector to receive name '%s', got '%s'", name, collector.Prefix)
+		}
+	}
+}
+
+type MismatchTagProcessor struct{}
+
+func (mtp *MismatchTagProcessor) GetPriority() int { return 0 }
+
+func (mtp *MismatchTagProcessor) CanProcess(value string) bool { return value == "mismatch" }
+
+func (mtp *MismatchTagProcessor) Process(ctx context.Context, sc *ServiceContainer, field reflect.StructField, value string) (any, error) {
+	return "not a logger", nil
+}
+
+type MismatchAgent struct {
+	Logger *LoggerService `fabric:"mismatch"`
+}
+
+func TestUnassignableProcessorResult(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	sc.AddTagProcessor(&MismatchTagProcessor{})
+
+	if err := Register[*MismatchAgent](sc); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	_, err := Resolve[*MismatchAgent](ctx, sc)
+	if err == nil {
+		t.Fatal("Expected resolution with mismatching processor result to fail")
+	}
+
+	if !strings.Contains(err.Error(), "not assignable") || !strings.Contains(err.Error(), "Logger") {
+		t.Errorf("Expected descriptive assignability error, got: %v", err)
+	}
+}
+
+type WideAgent struct {
+	Logger01  *LoggerService  `fabric:"inject,optional"`
+	Logger02  *LoggerService  `fabric:"inject,optional"`
+	Logger03  *LoggerService  `fabric:"inject,optional"`
+	Logger04  *LoggerService  `fabric:"inject,optional"`
+	Logger05  *LoggerService  `fabric:"inject,optional"`
+	Logger06  *LoggerService  `fabric:"inject,optional"`
+	Logger07  *LoggerService  `fabric:"inject,optional"`
+	Logger08  *LoggerService  `fabric:"inject,optional"`
+	Encrypt01 *EncryptService `fabric:"inject,optional"`
+	Encrypt02 *EncryptService `fabric:"inject,optional"`
+	Encrypt03 *EncryptService `fabric:"inject,optional"`
+	Encrypt04 *EncryptService `fabric:"inject,optional"`
+	Encrypt05 *EncryptService `fabric:"inject,optional"`
+	Encrypt06 *EncryptService `fabric:"inject,optional"`
+	Encrypt07 *EncryptService `fabric:"inject,optional"`
+	Encrypt08 *EncryptService `fabric:"inject,optional"`
+	Name      string
+	Count     int
+}
+
+func TestFabricFieldsPlanShared(t *testing.T) {
+	plans := fabricFields(typeKey[*WideAgent]())
+	if len(plans) != 16 {
+		t.Fatalf("Expected 16 planned fields, got %d", len(plans))
+	}
+
+	if plans[0].tag != "inject" || !plans[0].flags.optional {
+		t.Errorf("Expected parsed tag and flags, got %+v", plans[0])
+	}
+
+	again := fabricFields(typeKey[WideAgent]())
+	if &again[0] != &plans[0] {
+		t.Error("Expected plan to be cached per struct type")
+	}
+
+	if plans := fabricFields(typeKey[LoggerEngine]()); len(plans) != 0 {
+		t.Errorf("Expected no plan for non-struct types, got %d fields", len(plans))
+	}
+}
+
+func BenchmarkRegisterWideStruct(b *testing.B) {
+	sc := NewServiceContainer()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := Register[*WideAgent](sc); err != nil {
+			b.Fatalf("Failed to complete service registration: %v", err)
+		}
+	}
+}
+
+type ChainRoot struct {
+	Branch *ChainBranch `fabric:"inject"`
+}
+
+type ChainBranch struct {
+	Logger LoggerEngine `fabric:"inject"`
+}
+
+func TestFabricTagErrorNamesFieldChain(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	errs.Add(Register[*ChainRoot](sc))
+	errs.Add(Register[*ChainBranch](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	_, err := Resolve[*ChainRoot](ctx, sc)
+	if !errors.Is(err, ErrNotRegistered) {
+		t.Fatalf("Expected ErrNotRegistered, got %v", err)
+	}
+
+	expected := "failed to construct *container.ChainRoot -> field Branch (*container.ChainBranch) -> field Logger (container.LoggerEngine): "
+	if !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("Expected error to name the full field chain, got '%v'", err)
+	}
+
+	if strings.Count(err.Error(), "ChainRoot") != 1 {
+		t.Errorf("Expected every level to appear once, got '%v'", err)
+	}
+}
+
+func TestInjectErrorListsNamedCandidates(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	errs := &Errors{}
+	for _, name := range []string{"redis", "memory", "noop"} {
+		errs.Add(Register[*NamedLogger](sc, AsNamed(name), WithName[LoggerEngine](name), namedLoggerFactory(name)))
+	}
+	errs.Add(Register[*EncryptService](sc, With[EncryptEngine]()))
+	errs.Add(Register[*Agent](sc))
+
+	if err := errs.Errors(); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	_, err := Resolve[*Agent](ctx, sc)
+	if !errors.Is(err, ErrNotRegistered) {
+		t.Fatalf("Expected ErrNotRegistered for ambiguous injection, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "found 3 named registrations: memory, noop, redis - specify one via inject:name") {
+		t.Errorf("Expected error to list the named candidates, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package container
+
+import (
+	"log"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// resolutionTimer records the construction time of services when timing is enabled.
+// It uses its own mutex, allowing durations to be recorded while the container lock is held.
+type resolutionTimer struct {
+	mu sync.Mutex
+
+	// enabled indicates whether construction times are recorded
+	enabled bool
+
+	// threshold is the duration after which a single construction is logged as slow
+	threshold time.Duration
+
+	// stats contains the cumulative construction time per type
+	stats map[reflect.Type]time.Duration
+}
+
+// EnableTiming enables the recording of construction times. Once enabled, the duration
+// of every factory and lifecycle Init call is recorded and accumulated per type, and can
+// be retrieved via ResolutionStats. Timing is disabled by default, adding no overhead.
+//
+// Example:
+//
+//	container.EnableTiming()
+//	container.SetSlowThreshold(100 * time.Millisecond)
+func (sc *ServiceContainer) EnableTiming() {
+	sc.timer.mu.Lock()
+	sc.timer.enabled = true
+	if sc.timer.stats == nil {
+		sc.timer.stats = make(map[reflect.Type]time.Duration)
+	}
+	sc.timer.mu.Unlock()
+}
+
+// SetSlowThreshold sets the duration after which a single factory or Init call is
+// logged as a slow construction. A threshold of zero disables the warning.
+// The threshold only applies if timing has been enabled via EnableTiming.
+func (sc *ServiceContainer) SetSlowThreshold(threshold time.Duration) {
+	sc.timer.mu.Lock()
+	sc.timer.threshold = threshold
+	sc.timer.mu.Unlock()
+}
+
+// ResolutionStats returns a copy of the cumulative construction time per type,
+// including the time spent in factories and lifecycle Init calls. The duration of a
+// factory includes the construction of all dependencies it resolves. An empty map is
+// returned if timing has not been enabled.
+//
+// Example:
+//
+//	for t, d := range container.ResolutionStats() {
+//		log.Printf("%s took %s", t, d)
+//	}
+func (sc *ServiceContainer) ResolutionStats() map[reflect.Type]time.Duration {
+	sc.timer.mu.Lock()
+	defer sc.timer.mu.Unlock()
+
+	stats := make(map[reflect.Type]time.Duration, len(sc.timer.stats))
+	for t, d := range sc.timer.stats {
+		stats[t] = d
+	}
+
+	return stats
+}
+
+// start returns the current time if timing is enabled.
+func (rt *resolutionTimer) start() (time.Time, bool) {
+	rt.mu.Lock()
+	enabled := rt.enabled
+	rt.mu.Unlock()
+
+	if !enabled {
+		return time.Time{}, false
+	}
+
+	return time.Now(), true
+}
+
+// record adds the duration since started to the stats of the provided type and
+// logs a warning if the duration exceeds the configured slow threshold.
+func (rt *resolutionTimer) record(t reflect.Type, stage string, started time.Time) {
+	duration := time.Since(started)
+
+	rt.mu.Lock()
+	rt.stats[t] += duration
+	threshold := rt.threshold
+	rt.mu.Unlock()
+
+	if threshold > 0 && duration > threshold {
+		log.Printf("[WARN] slow %s for '%s' took %s (threshold %s)", stage, t, duration, threshold)
+	}
+}
@@ -0,0 +1,40 @@
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestResolutionStats(t *testing.T) {
+	sc := NewServiceContainer()
+	ctx := t.Context()
+
+	if err := Register[*LoggerService](sc,
+		With[LoggerEngine](),
+		AsFactory(func(ctx context.Context, sc *ServiceContainer) (any, error) {
+			time.Sleep(5 * time.Millisecond)
+			return &LoggerService{}, nil
+		})); err != nil {
+		t.Fatalf("Failed to complete service registration: %v", err)
+	}
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	if len(sc.ResolutionStats()) != 0 {
+		t.Error("Expected no stats to be recorded while timing is disabled")
+	}
+
+	sc.EnableTiming()
+
+	if _, err := Resolve[LoggerEngine](ctx, sc); err != nil {
+		t.Fatalf("Failed to resolve logger: %v", err)
+	}
+
+	stats := sc.ResolutionStats()
+	if stats[typeKey[*LoggerService]()] < 5*time.Millisecond {
+		t.Errorf("Expected construction time to be recorded for concrete type, got %v", stats)
+	}
+}